@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+)
+
+// APIError is a failed Monad API call made through one of the Client
+// methods. It wraps the SDK error, so errors.As still finds a
+// *monad.GenericOpenAPIError, and records the response status.
+type APIError struct {
+	// StatusCode is the HTTP status of the response, or 0 when none was
+	// received (e.g. a network error).
+	StatusCode int
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiError wraps a non-nil err from an SDK call as an *APIError.
+func apiError(resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	apiErr := &APIError{Err: err}
+	if resp != nil {
+		apiErr.StatusCode = resp.StatusCode
+	}
+	return apiErr
+}
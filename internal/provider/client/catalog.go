@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// DefaultCatalogTTL is how long a fetched connector catalog is reused when the
+// client has no CatalogTTL configured.
+const DefaultCatalogTTL = 10 * time.Minute
+
+// catalogCache holds a fetched catalog for the lifetime of a provider
+// instance, refetching it once it is older than the TTL. Terraform plans
+// resources concurrently, so the lock is held across the fetch: concurrent
+// callers wait for the one in-flight request instead of issuing their own.
+type catalogCache[T any] struct {
+	mu        sync.Mutex
+	items     []T
+	fetchedAt time.Time
+}
+
+func (c *catalogCache[T]) get(
+	ctx context.Context,
+	ttl time.Duration,
+	fetch func(ctx context.Context) ([]T, *http.Response, error),
+) ([]T, *http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		ttl = DefaultCatalogTTL
+	}
+	if !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < ttl {
+		return c.items, nil, nil
+	}
+
+	items, resp, err := fetch(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	c.items = items
+	c.fetchedAt = time.Now()
+	return items, resp, nil
+}
+
+// FirstCatalogFailure records that a catalog could not be fetched and
+// reports whether it is the first such failure for this client, so callers
+// can warn about skipped validation once per provider instance instead of
+// once per resource.
+func (c *Client) FirstCatalogFailure() bool {
+	return c.catalogFailed.CompareAndSwap(false, true)
+}
+
+// InputCatalog returns the catalog of input connector types.
+func (c *Client) InputCatalog(ctx context.Context) ([]monad.InputsConnectorMeta, *http.Response, error) {
+	return c.inputCatalog.get(ctx, c.CatalogTTL, func(ctx context.Context) ([]monad.InputsConnectorMeta, *http.Response, error) {
+		return c.InputsAPI.V1InputsGet(ctx).Execute()
+	})
+}
+
+// OutputCatalog returns the catalog of output connector types.
+func (c *Client) OutputCatalog(ctx context.Context) ([]monad.OutputsConnectorMeta, *http.Response, error) {
+	return c.outputCatalog.get(ctx, c.CatalogTTL, func(ctx context.Context) ([]monad.OutputsConnectorMeta, *http.Response, error) {
+		return c.OutputsAPI.V1OutputsGet(ctx).Execute()
+	})
+}
+
+// InputTypes returns the type ids listed in the input catalog.
+func (c *Client) InputTypes(ctx context.Context) ([]string, *http.Response, error) {
+	catalog, resp, err := c.InputCatalog(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	typeIDs := make([]string, 0, len(catalog))
+	for _, meta := range catalog {
+		if meta.TypeId != nil {
+			typeIDs = append(typeIDs, *meta.TypeId)
+		}
+	}
+	return typeIDs, resp, nil
+}
+
+// OutputTypes returns the type ids listed in the output catalog.
+func (c *Client) OutputTypes(ctx context.Context) ([]string, *http.Response, error) {
+	catalog, resp, err := c.OutputCatalog(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	typeIDs := make([]string, 0, len(catalog))
+	for _, meta := range catalog {
+		if meta.TypeId != nil {
+			typeIDs = append(typeIDs, *meta.TypeId)
+		}
+	}
+	return typeIDs, resp, nil
+}
+
+// configSchema is the subset of a catalog entry's JSON Schema `config` needed
+// to read the allowed values of a setting.
+type configSchema struct {
+	Properties map[string]*configSchema `json:"properties"`
+	Enum       []any                    `json:"enum"`
+}
+
+// InputSettingValues returns the values the catalog allows for one setting of
+// an input type (the setting's JSON Schema `enum`). It returns nil when the
+// type or setting is not in the catalog or the setting is unconstrained.
+func (c *Client) InputSettingValues(ctx context.Context, typeID, setting string) ([]string, *http.Response, error) {
+	catalog, resp, err := c.InputCatalog(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for _, meta := range catalog {
+		if meta.GetTypeId() != typeID || meta.Config == nil {
+			continue
+		}
+
+		raw, err := json.Marshal(meta.Config)
+		if err != nil {
+			return nil, resp, err
+		}
+		var schema configSchema
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return nil, resp, err
+		}
+
+		// Settings are either top-level properties or nested under `settings`.
+		prop := schema.Properties[setting]
+		if prop == nil && schema.Properties["settings"] != nil {
+			prop = schema.Properties["settings"].Properties[setting]
+		}
+		if prop == nil {
+			return nil, resp, nil
+		}
+
+		values := make([]string, 0, len(prop.Enum))
+		for _, v := range prop.Enum {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, resp, nil
+	}
+	return nil, resp, nil
+}
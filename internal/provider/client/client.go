@@ -4,15 +4,70 @@ import (
 	"crypto/tls"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	monad "github.com/monad-inc/sdk/go"
 )
 
+// DefaultIdleConnTimeout is how long an idle keep-alive connection to the API
+// is kept open, matching net/http's default transport.
+const DefaultIdleConnTimeout = 90 * time.Second
+
 type Client struct {
 	*monad.APIClient
 
 	OrganizationID string
+
+	// DefaultDescription is applied to resources created without an explicit
+	// description. Empty means no default.
+	DefaultDescription string
+
+	// PageSize is the number of items requested per page by the List
+	// helpers. Zero means DefaultPageSize.
+	PageSize int32
+
+	// ValidateComponents enables plan-time checks that every pipeline node
+	// references an existing component of its declared type.
+	ValidateComponents bool
+
+	// ValidateUniqueNames enables plan-time checks that a new input or output
+	// does not reuse the name of an existing one.
+	ValidateUniqueNames bool
+
+	// DefaultBatchSize is applied to batching outputs created or updated
+	// without a batch size. Zero means no default.
+	DefaultBatchSize int64
+
+	// MaxRetries is how many times a request that failed transiently is
+	// retried. NewMonadAPIClient sets it to DefaultMaxRetries.
+	MaxRetries int
+
+	// PipelineMaxRetries, when set, overrides MaxRetries for pipeline
+	// operations.
+	PipelineMaxRetries *int
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between
+	// retries. NewMonadAPIClient sets them to DefaultRetryWaitMin and
+	// DefaultRetryWaitMax.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// CatalogTTL is how long the connector catalogs are cached. Zero means
+	// DefaultCatalogTTL.
+	CatalogTTL time.Duration
+
+	// MaxErrorBodyBytes is how much of an API response body is quoted in an
+	// error diagnostic. Zero means the provider's default.
+	MaxErrorBodyBytes int
+
+	inputCatalog  catalogCache[monad.InputsConnectorMeta]
+	outputCatalog catalogCache[monad.OutputsConnectorMeta]
+
+	catalogFailed atomic.Bool
+
+	// httpTransport is the innermost transport, which owns the connections.
+	httpTransport *http.Transport
 }
 
 func NewMonadAPIClient(host, apiToken, organizationID string, isInsecure bool) *Client {
@@ -23,28 +78,52 @@ func NewMonadAPIClient(host, apiToken, organizationID string, isInsecure bool) *
 		debug = true
 	}
 
-	return &Client{
+	c := &Client{
 		OrganizationID: organizationID,
-		APIClient: monad.NewAPIClient(&monad.Configuration{
-			Debug:     debug,
-			UserAgent: "terraform-provider-monad/1.0",
-			Scheme:    "https",
-			Servers: []monad.ServerConfiguration{
-				{
-					URL: host + "/api",
-				},
+		MaxRetries:     DefaultMaxRetries,
+		RetryWaitMin:   DefaultRetryWaitMin,
+		RetryWaitMax:   DefaultRetryWaitMax,
+		// A custom TLSClientConfig disables net/http's automatic HTTP/2, so
+		// it is requested explicitly.
+		httpTransport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: isInsecure,
 			},
-			HTTPClient: &http.Client{
-				Timeout: time.Minute,
-				Transport: &transport{
+			ForceAttemptHTTP2: true,
+			IdleConnTimeout:   DefaultIdleConnTimeout,
+		},
+	}
+	c.APIClient = monad.NewAPIClient(&monad.Configuration{
+		Debug:     debug,
+		UserAgent: "terraform-provider-monad/1.0",
+		Scheme:    "https",
+		Servers: []monad.ServerConfiguration{
+			{
+				URL: host + "/api",
+			},
+		},
+		HTTPClient: &http.Client{
+			Transport: &deadlineTransport{
+				timeout: DefaultRequestTimeout,
+				next: &transport{
 					apiToken: apiToken,
-					next: &http.Transport{
-						TLSClientConfig: &tls.Config{
-							InsecureSkipVerify: isInsecure,
+					next: &retryTransport{
+						maxRetries: &c.MaxRetries,
+						waitMin:    &c.RetryWaitMin,
+						waitMax:    &c.RetryWaitMax,
+						next: &idempotencyTransport{
+							next: c.httpTransport,
 						},
 					},
 				},
 			},
-		}),
-	}
+		},
+	})
+	return c
+}
+
+// SetIdleConnTimeout sets how long idle keep-alive connections are kept open.
+// Zero means no limit. Call it before the client makes any requests.
+func (c *Client) SetIdleConnTimeout(d time.Duration) {
+	c.httpTransport.IdleConnTimeout = d
 }
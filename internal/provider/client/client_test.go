@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTransportSettings(t *testing.T) {
+	c := NewMonadAPIClient("https://api.example.com", "token", "org", false)
+
+	if !c.httpTransport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be enabled on the transport")
+	}
+	if got := c.httpTransport.IdleConnTimeout; got != DefaultIdleConnTimeout {
+		t.Errorf("expected the default idle timeout %s, got %s", DefaultIdleConnTimeout, got)
+	}
+
+	c.SetIdleConnTimeout(5 * time.Minute)
+	if got := c.httpTransport.IdleConnTimeout; got != 5*time.Minute {
+		t.Errorf("expected idle timeout 5m0s, got %s", got)
+	}
+
+	// The configured transport is the one requests go through.
+	var next http.RoundTripper = c.GetConfig().HTTPClient.Transport
+	for {
+		switch rt := next.(type) {
+		case *deadlineTransport:
+			next = rt.next
+			continue
+		case *transport:
+			next = rt.next
+			continue
+		case *retryTransport:
+			next = rt.next
+			continue
+		case *idempotencyTransport:
+			next = rt.next
+			continue
+		}
+		break
+	}
+	if next != c.httpTransport {
+		t.Errorf("expected requests to use the configured transport, got %T", next)
+	}
+}
+
+func TestDeadlineTransport(t *testing.T) {
+	var got *http.Request
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	transport := &deadlineTransport{timeout: time.Minute, next: next}
+
+	// Without a deadline, the default applies until the body is closed.
+	req, _ := http.NewRequest(http.MethodGet, "https://monad.test/api/v1/org/inputs", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline, ok := got.Context().Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("expected a deadline within 1m, got %v (set: %t)", deadline, ok)
+	}
+	if got.Context().Err() != nil {
+		t.Fatal("expected the context to stay live until the body is closed")
+	}
+	resp.Body.Close()
+	if got.Context().Err() == nil {
+		t.Error("expected closing the body to release the context")
+	}
+
+	// A caller's deadline, e.g. from a resource's timeouts block, is kept.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "https://monad.test/api/v1/org/inputs", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if deadline, _ := got.Context().Deadline(); !deadline.Equal(want) {
+		t.Errorf("expected the caller's deadline %v, got %v", want, deadline)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a request, including its retries, when the
+// caller's context has no deadline of its own.
+const DefaultRequestTimeout = time.Minute
+
+var _ http.RoundTripper = &deadlineTransport{}
+
+// deadlineTransport applies DefaultRequestTimeout to requests whose context
+// has no deadline. Resources with a `timeouts` block pass a context with the
+// configured deadline instead, which may be longer, so the limit cannot be an
+// http.Client.Timeout. The deadline covers reading the response body, so it
+// is released when the body is closed.
+type deadlineTransport struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a request's context once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
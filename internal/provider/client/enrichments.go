@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreateEnrichment creates an enrichment in organizationID.
+func (c *Client) CreateEnrichment(ctx context.Context, organizationID string, request monad.RoutesV3CreateEnrichmentRequest) (*monad.ModelsEnrichment, *http.Response, error) {
+	enrichment, resp, err := c.OrganizationEnrichmentsAPI.
+		V3OrganizationIdEnrichmentsPost(ctx, organizationID).
+		RoutesV3CreateEnrichmentRequest(request).
+		Execute()
+	return enrichment, resp, apiError(resp, err)
+}
+
+// GetEnrichment returns the enrichment id in organizationID.
+func (c *Client) GetEnrichment(ctx context.Context, organizationID, id string) (*monad.RoutesV3GetEnrichmentResponse, *http.Response, error) {
+	enrichment, resp, err := c.OrganizationEnrichmentsAPI.
+		V3OrganizationIdEnrichmentsEnrichmentIdGet(ctx, organizationID, id).
+		Execute()
+	return enrichment, resp, apiError(resp, err)
+}
+
+// UpdateEnrichment replaces the configuration of the enrichment id in
+// organizationID.
+func (c *Client) UpdateEnrichment(ctx context.Context, organizationID, id string, request monad.RoutesV3PutEnrichmentRequest) (*monad.ModelsEnrichment, *http.Response, error) {
+	enrichment, resp, err := c.OrganizationEnrichmentsAPI.
+		V3OrganizationIdEnrichmentsEnrichmentIdPut(ctx, organizationID, id).
+		RoutesV3PutEnrichmentRequest(request).
+		Execute()
+	return enrichment, resp, apiError(resp, err)
+}
+
+// DeleteEnrichment deletes the enrichment id in organizationID.
+func (c *Client) DeleteEnrichment(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	_, resp, err := c.OrganizationEnrichmentsAPI.
+		V3OrganizationIdEnrichmentsEnrichmentIdDelete(ctx, organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+var _ http.RoundTripper = &idempotencyTransport{}
+
+// idempotencyTransport sets an Idempotency-Key on POST (create) requests so the
+// API can recognise a retried create it has already processed. The key is
+// random per logical request: retryTransport stores it on the request context
+// so every retry sends the same key, while a separate create of an identical
+// resource gets a new one.
+type idempotencyTransport struct {
+	next http.RoundTripper
+}
+
+func (t *idempotencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || req.Header.Get(idempotencyKeyHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	key, ok := req.Context().Value(idempotencyKeyKey{}).(string)
+	if !ok {
+		var err error
+		if key, err = newIdempotencyKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	return t.next.RoundTrip(req)
+}
+
+type idempotencyKeyKey struct{}
+
+// withIdempotencyKey returns ctx carrying a new Idempotency-Key, which every
+// request made with it sends.
+func withIdempotencyKey(ctx context.Context) (context.Context, error) {
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, idempotencyKeyKey{}, key), nil
+}
+
+// newIdempotencyKey returns 128 random bits, hex-encoded.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
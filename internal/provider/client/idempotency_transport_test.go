@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordingTransport captures the last request instead of sending it.
+type recordingTransport struct {
+	last *http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.last = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestIdempotencyTransport(t *testing.T) {
+	roundTrip := func(t *testing.T, ctx context.Context, method, url, body string) *http.Request {
+		t.Helper()
+
+		rec := &recordingTransport{}
+		transport := &idempotencyTransport{next: rec}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		return rec.last
+	}
+
+	const inputsURL = "https://monad.test/api/v2/org/inputs"
+	ctx := context.Background()
+
+	first := roundTrip(t, ctx, http.MethodPost, inputsURL, `{"name":"a"}`)
+	again := roundTrip(t, ctx, http.MethodPost, inputsURL, `{"name":"a"}`)
+
+	key := first.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		t.Fatal("expected an Idempotency-Key on POST")
+	}
+	// Two creates of an identical resource are two requests, not one retried.
+	if got := again.Header.Get(idempotencyKeyHeader); got == key {
+		t.Errorf("separate requests: expected a new key, got %q again", got)
+	}
+
+	keyed, err := withIdempotencyKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := keyed.Value(idempotencyKeyKey{}).(string)
+	for i := 0; i < 2; i++ {
+		if got := roundTrip(t, keyed, http.MethodPost, inputsURL, `{"name":"a"}`).Header.Get(idempotencyKeyHeader); got != want {
+			t.Errorf("attempt %d: expected the context key %q, got %q", i, want, got)
+		}
+	}
+
+	// The body must still reach the next transport intact.
+	body, err := io.ReadAll(first.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"name":"a"}` {
+		t.Errorf("body not preserved, got %q", body)
+	}
+
+	if got := roundTrip(t, ctx, http.MethodPatch, inputsURL, `{"name":"a"}`).Header.Get(idempotencyKeyHeader); got != "" {
+		t.Errorf("expected no Idempotency-Key on PATCH, got %q", got)
+	}
+}
+
+func TestRetryTransportReusesIdempotencyKey(t *testing.T) {
+	// The first create is retried after a 503; the second is a new request.
+	statuses := []int{http.StatusServiceUnavailable, http.StatusCreated, http.StatusCreated}
+	var keys []string
+	next := &idempotencyTransport{next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		keys = append(keys, req.Header.Get(idempotencyKeyHeader))
+		status := statuses[len(keys)-1]
+		return &http.Response{StatusCode: status, Header: http.Header{"Retry-After": {"0"}}, Body: http.NoBody, Request: req}, nil
+	})}
+	maxRetries := 1
+	transport := &retryTransport{maxRetries: &maxRetries, next: next}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPost, "https://monad.test/api/v2/org/inputs", strings.NewReader(`{"name":"a"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[1] != keys[0] {
+		t.Errorf("expected the retry to reuse the key, got %q", keys[:2])
+	}
+	if keys[2] == keys[0] {
+		t.Errorf("expected a new key for a new request, got %q again", keys[2])
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreateInput creates an input in organizationID.
+func (c *Client) CreateInput(ctx context.Context, organizationID string, request monad.RoutesV2CreateInputRequest) (*monad.ModelsInput, *http.Response, error) {
+	input, resp, err := c.OrganizationInputsAPI.
+		V2OrganizationIdInputsPost(ctx, organizationID).
+		RoutesV2CreateInputRequest(request).
+		Execute()
+	return input, resp, apiError(resp, err)
+}
+
+// GetInput returns the input id in organizationID.
+func (c *Client) GetInput(ctx context.Context, organizationID, id string) (*monad.RoutesGetInputResponse, *http.Response, error) {
+	input, resp, err := c.OrganizationInputsAPI.
+		V1OrganizationIdInputsInputIdGet(ctx, organizationID, id).
+		Execute()
+	return input, resp, apiError(resp, err)
+}
+
+// UpdateInput replaces the configuration of the input id in organizationID.
+func (c *Client) UpdateInput(ctx context.Context, organizationID, id string, request monad.RoutesV2PutInputRequest) (*monad.ModelsInput, *http.Response, error) {
+	input, resp, err := c.OrganizationInputsAPI.
+		V2OrganizationIdInputsInputIdPut(ctx, organizationID, id).
+		RoutesV2PutInputRequest(request).
+		Execute()
+	return input, resp, apiError(resp, err)
+}
+
+// DeleteInput deletes the input id in organizationID.
+func (c *Client) DeleteInput(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	_, resp, err := c.OrganizationInputsAPI.
+		V1OrganizationIdInputsInputIdDelete(ctx, organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreateOutput creates an output in organizationID.
+func (c *Client) CreateOutput(ctx context.Context, organizationID string, request monad.RoutesV2CreateOutputRequest) (*monad.ModelsOutput, *http.Response, error) {
+	output, resp, err := c.OrganizationOutputsAPI.
+		V2OrganizationIdOutputsPost(ctx, organizationID).
+		RoutesV2CreateOutputRequest(request).
+		Execute()
+	return output, resp, apiError(resp, err)
+}
+
+// GetOutput returns the output id in organizationID.
+func (c *Client) GetOutput(ctx context.Context, organizationID, id string) (*monad.RoutesGetOutputResponse, *http.Response, error) {
+	output, resp, err := c.OrganizationOutputsAPI.
+		V1OrganizationIdOutputsOutputIdGet(ctx, organizationID, id).
+		Execute()
+	return output, resp, apiError(resp, err)
+}
+
+// UpdateOutput replaces the configuration of the output id in organizationID.
+func (c *Client) UpdateOutput(ctx context.Context, organizationID, id string, request monad.RoutesV2PutOutputRequest) (*monad.ModelsOutput, *http.Response, error) {
+	output, resp, err := c.OrganizationOutputsAPI.
+		V2OrganizationIdOutputsOutputIdPut(ctx, organizationID, id).
+		RoutesV2PutOutputRequest(request).
+		Execute()
+	return output, resp, apiError(resp, err)
+}
+
+// DeleteOutput deletes the output id in organizationID.
+func (c *Client) DeleteOutput(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	_, resp, err := c.OrganizationOutputsAPI.
+		V1OrganizationIdOutputsOutputIdDelete(ctx, organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
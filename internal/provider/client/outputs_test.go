@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+func TestOutputMethods(t *testing.T) {
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v2/org/outputs", "PUT /api/v2/org/outputs/out-1":
+			_, _ = w.Write([]byte(`{"id": "out-1", "name": "warehouse"}`))
+		case "GET /api/v1/org/outputs/out-1":
+			_, _ = w.Write([]byte(`{"id": "out-1", "name": "warehouse", "type": "http"}`))
+		case "DELETE /api/v1/org/outputs/out-1":
+			_, _ = w.Write([]byte(`"ok"`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := NewMonadAPIClient(server.URL, "token", "org", true)
+	name := "warehouse"
+
+	created, _, err := c.CreateOutput(ctx, "org", monad.RoutesV2CreateOutputRequest{Name: &name})
+	if err != nil || created.GetId() != "out-1" {
+		t.Fatalf("create: got %v, %v", created, err)
+	}
+	got, _, err := c.GetOutput(ctx, "org", "out-1")
+	if err != nil || got.GetType() != "http" {
+		t.Fatalf("get: got %v, %v", got, err)
+	}
+	if _, _, err := c.UpdateOutput(ctx, "org", "out-1", monad.RoutesV2PutOutputRequest{Name: &name}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := c.DeleteOutput(ctx, "org", "out-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	want := []string{
+		"POST /api/v2/org/outputs",
+		"GET /api/v1/org/outputs/out-1",
+		"PUT /api/v2/org/outputs/out-1",
+		"DELETE /api/v1/org/outputs/out-1",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("expected calls %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d: expected %s, got %s", i, want[i], calls[i])
+		}
+	}
+
+	// Failures are typed, and still unwrap to the SDK error.
+	_, resp, err := c.GetOutput(ctx, "org", "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || resp == nil {
+		t.Errorf("expected an APIError with the response status, got %v", err)
+	}
+	var sdkErr *monad.GenericOpenAPIError
+	if !errors.As(err, &sdkErr) {
+		t.Errorf("expected the SDK error to be wrapped, got %T", err)
+	}
+}
+
+func TestAPIErrorWithoutResponse(t *testing.T) {
+	err := apiError(nil, errors.New("connection refused"))
+	if IsNotFound(err) {
+		t.Error("a network error is not a not found error")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 0 || err.Error() != "connection refused" {
+		t.Errorf("unexpected error %#v", err)
+	}
+	if apiError(nil, nil) != nil {
+		t.Error("expected no error for a successful call")
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// DefaultPageSize is the page size used by the List helpers when the client
+// has no PageSize configured.
+const DefaultPageSize int32 = 100
+
+// fetchPage requests one page of up to limit items starting at offset. It
+// returns the items alongside the pagination block from the API response.
+type fetchPage[T any] func(ctx context.Context, limit, offset int32) ([]T, *monad.ModelsPagination, *http.Response, error)
+
+// maxPages bounds how many pages paginate requests, so a server that keeps
+// answering with full pages cannot stall a plan indefinitely.
+const maxPages = 1000
+
+// paginate follows the API's limit/offset pagination and aggregates every
+// page in order. It stops once the reported total is reached, a page comes
+// back short, or a page repeats the previous one (a server ignoring
+// `offset`), whichever happens first. It gives up with an error after
+// maxPages pages.
+func paginate[T any](ctx context.Context, pageSize int32, fetch fetchPage[T]) ([]T, *http.Response, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	var all, previous []T
+	var offset int32
+	for page := 0; page < maxPages; page++ {
+		items, pagination, resp, err := fetch(ctx, pageSize, offset)
+		if err != nil {
+			return nil, resp, err
+		}
+		if len(items) > 0 && reflect.DeepEqual(items, previous) {
+			return all, resp, nil
+		}
+		all = append(all, items...)
+		offset += int32(len(items))
+		previous = items
+
+		if len(items) < int(pageSize) {
+			return all, resp, nil
+		}
+		if pagination != nil && pagination.Total != nil && offset >= *pagination.Total {
+			return all, resp, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("pagination did not finish after %d pages of %d items", maxPages, pageSize)
+}
+
+// ListInputs returns every input in organizationID.
+func (c *Client) ListInputs(ctx context.Context, organizationID string) ([]monad.ModelsInput, *http.Response, error) {
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsInput, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.OrganizationInputsAPI.
+			V1OrganizationIdInputsGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Inputs, list.Pagination, resp, nil
+	})
+}
+
+// ListOutputs returns every output in organizationID.
+func (c *Client) ListOutputs(ctx context.Context, organizationID string) ([]monad.ModelsOutput, *http.Response, error) {
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsOutput, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.OrganizationOutputsAPI.
+			V1OrganizationIdOutputsGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Outputs, list.Pagination, resp, nil
+	})
+}
+
+// ListEnrichments returns every enrichment in organizationID.
+func (c *Client) ListEnrichments(ctx context.Context, organizationID string) ([]monad.ModelsEnrichment, *http.Response, error) {
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsEnrichment, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.OrganizationEnrichmentsAPI.
+			V3OrganizationIdEnrichmentsGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Enrichments, list.Pagination, resp, nil
+	})
+}
+
+// ListTransforms returns every transform in organizationID.
+func (c *Client) ListTransforms(ctx context.Context, organizationID string) ([]monad.ModelsTransform, *http.Response, error) {
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsTransform, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.OrganizationTransformsAPI.
+			V1OrganizationIdTransformsGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Transforms, list.Pagination, resp, nil
+	})
+}
+
+// ListSecrets returns the metadata of every secret in organizationID.
+func (c *Client) ListSecrets(ctx context.Context, organizationID string) ([]monad.ModelsSecretWithComponents, *http.Response, error) {
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsSecretWithComponents, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.SecretsAPI.
+			V2OrganizationIdSecretsGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Secrets, list.Pagination, resp, nil
+	})
+}
+
+// ListPipelines returns every pipeline in organizationID, with the pipeline
+// retry budget (see WithPipelineRetries).
+func (c *Client) ListPipelines(ctx context.Context, organizationID string) ([]monad.ModelsPipeline, *http.Response, error) {
+	ctx = c.WithPipelineRetries(ctx)
+	return paginate(ctx, c.PageSize, func(ctx context.Context, limit, offset int32) ([]monad.ModelsPipeline, *monad.ModelsPagination, *http.Response, error) {
+		list, resp, err := c.PipelinesAPI.
+			V2OrganizationIdPipelinesGet(ctx, organizationID).
+			Limit(limit).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		return list.Pipelines, list.Pagination, resp, nil
+	})
+}
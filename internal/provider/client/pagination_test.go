@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+func TestListOutputsCollectsAllPages(t *testing.T) {
+	const total = 5
+	var requests []string
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/org/outputs" {
+			http.NotFound(w, r)
+			return
+		}
+		requests = append(requests, r.URL.RawQuery)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		outputs := []map[string]any{}
+		for i := offset; i < offset+limit && i < total; i++ {
+			outputs = append(outputs, map[string]any{"id": fmt.Sprintf("out-%d", i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"outputs": outputs,
+			"pagination": map[string]any{
+				"limit":  limit,
+				"offset": offset,
+				"total":  total,
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := NewMonadAPIClient(server.URL, "token", "org", true)
+	c.PageSize = 2
+
+	outputs, _, err := c.ListOutputs(context.Background(), "org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 3 {
+		t.Errorf("expected 3 page requests, got %d: %v", len(requests), requests)
+	}
+	if len(outputs) != total {
+		t.Fatalf("expected %d outputs, got %d", total, len(outputs))
+	}
+	for i, output := range outputs {
+		if want := fmt.Sprintf("out-%d", i); output.GetId() != want {
+			t.Errorf("output %d: expected id %q, got %q", i, want, output.GetId())
+		}
+	}
+}
+
+func TestPaginateStopsWhenOffsetIsIgnored(t *testing.T) {
+	var requests int
+	items, _, err := paginate(context.Background(), 2, func(ctx context.Context, limit, offset int32) ([]string, *monad.ModelsPagination, *http.Response, error) {
+		requests++
+		return []string{"a", "b"}, nil, nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 page requests, got %d", requests)
+	}
+	if len(items) != 2 {
+		t.Errorf("expected the repeated page to be dropped, got %v", items)
+	}
+}
+
+func TestPaginateGivesUpAfterMaxPages(t *testing.T) {
+	var requests int
+	_, _, err := paginate(context.Background(), 1, func(ctx context.Context, limit, offset int32) ([]int32, *monad.ModelsPagination, *http.Response, error) {
+		requests++
+		return []int32{offset}, nil, nil, nil
+	})
+	if err == nil {
+		t.Fatal("expected an error for a pagination that never finishes")
+	}
+	if requests != maxPages {
+		t.Errorf("expected %d page requests, got %d", maxPages, requests)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreatePipeline creates a pipeline in organizationID, with the
+// pipeline retry budget (see WithPipelineRetries).
+func (c *Client) CreatePipeline(ctx context.Context, organizationID string, request monad.RoutesV2CreatePipelineRequest) (*monad.ModelsPipelineConfigV2, *http.Response, error) {
+	pipeline, resp, err := c.PipelinesAPI.
+		V2OrganizationIdPipelinesPost(c.WithPipelineRetries(ctx), organizationID).
+		RoutesV2CreatePipelineRequest(request).
+		Execute()
+	return pipeline, resp, apiError(resp, err)
+}
+
+// GetPipeline returns the pipeline id in organizationID, with the
+// pipeline retry budget (see WithPipelineRetries).
+func (c *Client) GetPipeline(ctx context.Context, organizationID, id string) (*monad.ModelsPipelineConfigV2, *http.Response, error) {
+	pipeline, resp, err := c.PipelinesAPI.
+		V2OrganizationIdPipelinesPipelineIdGet(c.WithPipelineRetries(ctx), organizationID, id).
+		Execute()
+	return pipeline, resp, apiError(resp, err)
+}
+
+// UpdatePipeline updates the pipeline id in organizationID, with the
+// pipeline retry budget (see WithPipelineRetries).
+func (c *Client) UpdatePipeline(ctx context.Context, organizationID, id string, request monad.RoutesV2UpdatePipelineRequest) (*monad.ModelsPipelineConfigV2, *http.Response, error) {
+	pipeline, resp, err := c.PipelinesAPI.
+		V2OrganizationIdPipelinesPipelineIdPatch(c.WithPipelineRetries(ctx), organizationID, id).
+		RoutesV2UpdatePipelineRequest(request).
+		Execute()
+	return pipeline, resp, apiError(resp, err)
+}
+
+// DeletePipeline deletes the pipeline id in organizationID, with the
+// pipeline retry budget (see WithPipelineRetries).
+func (c *Client) DeletePipeline(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	_, resp, err := c.PipelinesAPI.
+		V2OrganizationIdPipelinesPipelineIdDelete(c.WithPipelineRetries(ctx), organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxRetries is how many times a request that failed transiently is
+// retried when the client has no MaxRetries configured.
+const DefaultMaxRetries = 3
+
+// DefaultRetryWaitMin and DefaultRetryWaitMax bound the backoff between
+// retries when the client has no RetryWaitMin or RetryWaitMax configured.
+const (
+	DefaultRetryWaitMin = 500 * time.Millisecond
+	DefaultRetryWaitMax = 10 * time.Second
+)
+
+var _ http.RoundTripper = &retryTransport{}
+
+// retryTransport retries requests that failed transiently, backing off
+// exponentially or as long as the server's Retry-After asks. Idempotent
+// requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried after a network
+// error or a 429, 502, 503 or 504. Other requests (POST, PATCH) may have been
+// processed when the connection dropped or a gateway timed out, so they are
+// only retried on a 429 or 503, which the API returns before doing any work.
+type retryTransport struct {
+	// maxRetries, waitMin and waitMax point at the Client fields of the same
+	// name so they can be configured after the client is built.
+	maxRetries *int
+	waitMin    *time.Duration
+	waitMax    *time.Duration
+	next       http.RoundTripper
+}
+
+type maxRetriesKey struct{}
+
+// WithMaxRetries returns a context whose requests are retried up to n times,
+// overriding Client.MaxRetries for a single operation.
+func WithMaxRetries(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, maxRetriesKey{}, n)
+}
+
+// WithPipelineRetries applies PipelineMaxRetries, when set, to ctx. Pipeline
+// create/update are heavier than other calls, so they can be given their own
+// retry budget.
+func (c *Client) WithPipelineRetries(ctx context.Context) context.Context {
+	if c.PipelineMaxRetries == nil {
+		return ctx
+	}
+	return WithMaxRetries(ctx, *c.PipelineMaxRetries)
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	maxRetries := DefaultMaxRetries
+	if t.maxRetries != nil {
+		maxRetries = *t.maxRetries
+	}
+	if n, ok := ctx.Value(maxRetriesKey{}).(int); ok {
+		maxRetries = n
+	}
+	// A body that cannot be replayed cannot be retried.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	// A POST carries one Idempotency-Key across all of its attempts.
+	if req.Method == http.MethodPost {
+		var err error
+		if ctx, err = withIdempotencyKey(ctx); err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+	}
+
+	waitMin, waitMax := t.waitBounds()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= maxRetries || !retryable(ctx, req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := retryWait(attempt, resp, waitMin, waitMax)
+		fields := map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			// Drain so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.Debug(ctx, "retrying Monad API request", fields)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryable reports whether a method request that produced resp or err may
+// succeed if sent again without risking it being applied twice.
+func retryable(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if !idempotent(method) {
+		return err == nil &&
+			(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable)
+	}
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// idempotent reports whether sending a method request twice has the same
+// effect as sending it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitBounds returns the configured backoff bounds, or the defaults.
+func (t *retryTransport) waitBounds() (time.Duration, time.Duration) {
+	waitMin, waitMax := DefaultRetryWaitMin, DefaultRetryWaitMax
+	if t.waitMin != nil {
+		waitMin = *t.waitMin
+	}
+	if t.waitMax != nil {
+		waitMax = *t.waitMax
+	}
+	return waitMin, waitMax
+}
+
+// retryWait is the delay before the retry following attempt: the server's
+// Retry-After (in seconds) when given, otherwise exponential backoff from
+// waitMin. Either way the delay is capped at waitMax.
+func retryWait(attempt int, resp *http.Response, waitMin, waitMax time.Duration) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, waitMax)
+		}
+	}
+	wait := waitMin
+	for i := 0; i < attempt && wait < waitMax; i++ {
+		wait *= 2
+	}
+	return min(wait, waitMax)
+}
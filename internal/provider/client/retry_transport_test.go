@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedTransport answers each request with the next status in statuses
+// (0 means a network error) and records the request bodies it saw.
+type scriptedTransport struct {
+	statuses []int
+	bodies   []string
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	t.bodies = append(t.bodies, string(body))
+
+	status := t.statuses[0]
+	if len(t.statuses) > 1 {
+		t.statuses = t.statuses[1:]
+	}
+	if status == 0 {
+		return nil, errors.New("connection reset by peer")
+	}
+	header := http.Header{}
+	header.Set("Retry-After", "0")
+	return &http.Response{StatusCode: status, Header: header, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	roundTrip := func(t *testing.T, ctx context.Context, method string, maxRetries int, statuses ...int) (*scriptedTransport, *http.Response, error) {
+		t.Helper()
+
+		next := &scriptedTransport{statuses: statuses}
+		transport := &retryTransport{maxRetries: &maxRetries, next: next}
+
+		req, err := http.NewRequestWithContext(ctx, method, "https://monad.test/api/v2/org/pipelines/p1", strings.NewReader(`{"name":"a"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := transport.RoundTrip(req)
+		return next, resp, err
+	}
+
+	ctx := context.Background()
+
+	t.Run("transient failures are retried", func(t *testing.T) {
+		next, resp, err := roundTrip(t, ctx, http.MethodPut, 3, 0, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected the final response, got %d", resp.StatusCode)
+		}
+		if len(next.bodies) != 4 {
+			t.Fatalf("expected 4 attempts, got %d", len(next.bodies))
+		}
+		for i, body := range next.bodies {
+			if body != `{"name":"a"}` {
+				t.Errorf("attempt %d: body not replayed, got %q", i, body)
+			}
+		}
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		next, resp, err := roundTrip(t, ctx, http.MethodPut, 2, http.StatusBadGateway)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusBadGateway || len(next.bodies) != 3 {
+			t.Errorf("expected 3 attempts ending in 502, got %d attempts and %d", len(next.bodies), resp.StatusCode)
+		}
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		next, resp, err := roundTrip(t, ctx, http.MethodPut, 3, http.StatusInternalServerError, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusInternalServerError || len(next.bodies) != 1 {
+			t.Errorf("expected a single attempt ending in 500, got %d attempts and %d", len(next.bodies), resp.StatusCode)
+		}
+	})
+
+	t.Run("context override", func(t *testing.T) {
+		next, _, err := roundTrip(t, WithMaxRetries(ctx, 0), http.MethodPut, 3, 0, http.StatusCreated)
+		if err == nil {
+			t.Error("expected the network error to be returned without retrying")
+		}
+		if len(next.bodies) != 1 {
+			t.Errorf("expected 1 attempt, got %d", len(next.bodies))
+		}
+	})
+
+	t.Run("POST is retried only when the request was not processed", func(t *testing.T) {
+		next, resp, err := roundTrip(t, ctx, http.MethodPost, 3, http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusCreated || len(next.bodies) != 3 {
+			t.Errorf("expected 3 attempts ending in 201, got %d attempts and %d", len(next.bodies), resp.StatusCode)
+		}
+
+		for _, status := range []int{0, http.StatusBadGateway, http.StatusGatewayTimeout} {
+			next, _, _ := roundTrip(t, ctx, http.MethodPost, 3, status, http.StatusCreated)
+			if len(next.bodies) != 1 {
+				t.Errorf("status %d: expected a single POST attempt, got %d", status, len(next.bodies))
+			}
+		}
+	})
+}
+
+func TestRetryWait(t *testing.T) {
+	waitMin, waitMax := DefaultRetryWaitMin, DefaultRetryWaitMax
+	if got := retryWait(0, nil, waitMin, waitMax); got != waitMin {
+		t.Errorf("first retry: expected %s, got %s", waitMin, got)
+	}
+	if got := retryWait(2, nil, waitMin, waitMax); got != 4*waitMin {
+		t.Errorf("third retry: expected %s, got %s", 4*waitMin, got)
+	}
+	if got := retryWait(30, nil, waitMin, waitMax); got != waitMax {
+		t.Errorf("expected backoff to be capped at %s, got %s", waitMax, got)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := retryWait(0, resp, waitMin, waitMax); got != 2*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", got)
+	}
+
+	// Configured bounds replace the defaults, including for Retry-After.
+	if got := retryWait(1, nil, 2*time.Second, time.Minute); got != 4*time.Second {
+		t.Errorf("custom minimum: expected 4s, got %s", got)
+	}
+	if got := retryWait(0, resp, 100*time.Millisecond, time.Second); got != time.Second {
+		t.Errorf("custom maximum: expected Retry-After to be capped at 1s, got %s", got)
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreateSecret creates a secret in organizationID.
+func (c *Client) CreateSecret(ctx context.Context, organizationID string, request monad.RoutesV2CreateOrUpdateSecretRequest) (*monad.RoutesV2SecretResponse, *http.Response, error) {
+	secret, resp, err := c.SecretsAPI.
+		V2OrganizationIdSecretsPost(ctx, organizationID).
+		RoutesV2CreateOrUpdateSecretRequest(request).
+		Execute()
+	return secret, resp, apiError(resp, err)
+}
+
+// GetSecret returns the metadata of the secret id in organizationID. Secret
+// values are never returned.
+func (c *Client) GetSecret(ctx context.Context, organizationID, id string) (*monad.ModelsSecretWithComponents, *http.Response, error) {
+	secret, resp, err := c.SecretsAPI.
+		V2OrganizationIdSecretsSecretIdGet(ctx, organizationID, id).
+		Execute()
+	return secret, resp, apiError(resp, err)
+}
+
+// UpdateSecret updates the secret id in organizationID.
+func (c *Client) UpdateSecret(ctx context.Context, organizationID, id string, request monad.RoutesV2CreateOrUpdateSecretRequest) (*monad.RoutesV2SecretResponse, *http.Response, error) {
+	secret, resp, err := c.SecretsAPI.
+		V2OrganizationIdSecretsSecretIdPatch(ctx, organizationID, id).
+		RoutesV2CreateOrUpdateSecretRequest(request).
+		Execute()
+	return secret, resp, apiError(resp, err)
+}
+
+// DeleteSecret deletes the secret id in organizationID.
+func (c *Client) DeleteSecret(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	resp, err := c.SecretsAPI.
+		V2OrganizationIdSecretsSecretIdDelete(ctx, organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
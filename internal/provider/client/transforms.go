@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	monad "github.com/monad-inc/sdk/go"
+)
+
+// CreateTransform creates a transform in organizationID.
+func (c *Client) CreateTransform(ctx context.Context, organizationID string, request monad.RoutesCreateTransformRequest) (*monad.ModelsTransform, *http.Response, error) {
+	transform, resp, err := c.OrganizationTransformsAPI.
+		V1OrganizationIdTransformsPost(ctx, organizationID).
+		RoutesCreateTransformRequest(request).
+		Execute()
+	return transform, resp, apiError(resp, err)
+}
+
+// GetTransform returns the transform id in organizationID.
+func (c *Client) GetTransform(ctx context.Context, organizationID, id string) (*monad.RoutesGetTransformResponse, *http.Response, error) {
+	transform, resp, err := c.OrganizationTransformsAPI.
+		// Unlike the other transform routes, the SDK takes the transform id first.
+		V1OrganizationIdTransformsTransformIdGet(ctx, id, organizationID).
+		Execute()
+	return transform, resp, apiError(resp, err)
+}
+
+// UpdateTransform updates the transform id in organizationID.
+func (c *Client) UpdateTransform(ctx context.Context, organizationID, id string, request monad.RoutesUpdateTransformRequest) (*monad.ModelsTransform, *http.Response, error) {
+	transform, resp, err := c.OrganizationTransformsAPI.
+		V1OrganizationIdTransformsTransformIdPatch(ctx, organizationID, id).
+		RoutesUpdateTransformRequest(request).
+		Execute()
+	return transform, resp, apiError(resp, err)
+}
+
+// DeleteTransform deletes the transform id in organizationID.
+func (c *Client) DeleteTransform(ctx context.Context, organizationID, id string) (*http.Response, error) {
+	_, resp, err := c.OrganizationTransformsAPI.
+		V1OrganizationIdTransformsTransformIdDelete(ctx, organizationID, id).
+		Execute()
+	return resp, apiError(resp, err)
+}
+
+// ApplyTransformation runs a transform config against a sample record in the
+// sandbox, without saving anything.
+func (c *Client) ApplyTransformation(ctx context.Context, request monad.RoutesV2ApplyTransformationRequest) (*monad.RoutesV2ApplyTransformationResponse, *http.Response, error) {
+	result, resp, err := c.SandboxAPI.
+		V2SandboxTransformPost(ctx).
+		RoutesV2ApplyTransformationRequest(request).
+		Execute()
+	return result, resp, apiError(resp, err)
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+var _ datasource.DataSource = &DataSourceConnector{}
+var _ datasource.DataSourceWithConfigure = &DataSourceConnector{}
+
+// DataSourceConnector reads an existing input or output by id. monad_input
+// and monad_output differ only in kind and in the client call that fetches
+// the connector.
+type DataSourceConnector struct {
+	client *client.Client
+	kind   string
+	get    func(ctx context.Context, c *client.Client, organizationID, id string) (*connectorDetails, *http.Response, error)
+}
+
+// connectorDetails is the part of an API input or output the data sources
+// expose.
+type connectorDetails struct {
+	ID            string
+	Name          string
+	Description   *string
+	ConnectorType string
+	Settings      map[string]any
+}
+
+type DataSourceConnectorModel struct {
+	ID             types.String  `tfsdk:"id"`
+	Name           types.String  `tfsdk:"name"`
+	Description    types.String  `tfsdk:"description"`
+	ComponentType  types.String  `tfsdk:"type"`
+	Config         types.Dynamic `tfsdk:"config"`
+	OrganizationID types.String  `tfsdk:"organization_id"`
+}
+
+func NewDataSourceInput() datasource.DataSource {
+	return &DataSourceConnector{
+		kind: "input",
+		get: func(ctx context.Context, c *client.Client, organizationID, id string) (*connectorDetails, *http.Response, error) {
+			input, resp, err := c.GetInput(ctx, organizationID, id)
+			if err != nil {
+				return nil, resp, err
+			}
+			return &connectorDetails{
+				ID:            input.GetId(),
+				Name:          input.GetName(),
+				Description:   input.Description,
+				ConnectorType: input.GetType(),
+				Settings:      input.GetConfig().Settings,
+			}, resp, nil
+		},
+	}
+}
+
+func NewDataSourceOutput() datasource.DataSource {
+	return &DataSourceConnector{
+		kind: "output",
+		get: func(ctx context.Context, c *client.Client, organizationID, id string) (*connectorDetails, *http.Response, error) {
+			output, resp, err := c.GetOutput(ctx, organizationID, id)
+			if err != nil {
+				return nil, resp, err
+			}
+			return &connectorDetails{
+				ID:            output.GetId(),
+				Name:          output.GetName(),
+				Description:   output.Description,
+				ConnectorType: output.GetType(),
+				Settings:      output.GetConfig().Settings,
+			}, resp, nil
+		},
+	}
+}
+
+func (d *DataSourceConnector) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.kind
+}
+
+func (d *DataSourceConnector) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *ClientData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *DataSourceConnector) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"An existing Monad %[1]s, looked up by id. Secrets are never returned, so `config` only holds the %[1]s's settings.",
+			d.kind,
+		),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Identifier of the %s", d.kind),
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Name of the %s", d.kind),
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Description of the %s", d.kind),
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Type of the %s, as reported by the API", d.kind),
+				Computed:            true,
+			},
+			"config": schema.DynamicAttribute{
+				MarkdownDescription: fmt.Sprintf(
+					"Live configuration of the %s: an object with its `settings`, in the shape a `monad_%s` resource's `config.settings` takes.",
+					d.kind, d.kind,
+				),
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Organization the %s belongs to. Defaults to the provider's `organization_id`.", d.kind),
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceConnector) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data DataSourceConnectorModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(d.client, data.OrganizationID)
+
+	connector, monadResp, err := d.get(ctx, d.client, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(d.client, &resp.Diagnostics, d.kind, data.ID.ValueString(), err, monadResp)
+		return
+	}
+
+	config, err := connectorConfigToTF(connector.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to convert %s settings", d.kind), err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(connector.ID)
+	data.Name = types.StringValue(connector.Name)
+	data.Description = stringOrNull(connector.Description)
+	data.ComponentType = types.StringValue(connector.ConnectorType)
+	data.Config = config
+	data.OrganizationID = types.StringValue(organizationID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// connectorConfigToTF converts the settings of an API connector into the
+// `config` object of the connector data sources, `{ settings = { ... } }`.
+// Connectors without settings read back as `{ settings = {} }`.
+func connectorConfigToTF(settings map[string]any) (types.Dynamic, error) {
+	if settings == nil {
+		settings = map[string]any{}
+	}
+	return AnyToDynamic(map[string]any{"settings": settings})
+}
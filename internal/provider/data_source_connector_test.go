@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataSourceConnectorReadByID(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		kind string
+		new  func() datasource.DataSource
+		path string
+		body string
+	}{
+		{
+			kind: "input",
+			new:  NewDataSourceInput,
+			path: "/api/v1/org/inputs/in-1",
+			body: `{"id": "in-1", "name": "okta-logs", "type": "okta", "description": "Okta system log",
+				"config": {"settings": {"org_url": "https://example.okta.com", "rate": 25}, "secrets": {"api_key": "do-not-leak"}}}`,
+		},
+		{
+			kind: "output",
+			new:  NewDataSourceOutput,
+			path: "/api/v1/org/outputs/in-1",
+			body: `{"id": "in-1", "name": "okta-logs", "type": "okta", "description": "Okta system log",
+				"config": {"settings": {"org_url": "https://example.okta.com", "rate": 25}, "secrets": {"api_key": "do-not-leak"}}}`,
+		},
+	} {
+		t.Run(tc.kind, func(t *testing.T) {
+			var gotPath string
+			d := tc.new()
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.body))
+			})
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: c}, &datasource.ConfigureResponse{})
+			s := dataSourceSchema(t, d)
+
+			config := dataSourceObjectValue(s, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "in-1"),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(config.Type(), nil)}}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+			if gotPath != tc.path {
+				t.Errorf("expected a lookup at %s, got %s", tc.path, gotPath)
+			}
+
+			var data DataSourceConnectorModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.Name.ValueString() != "okta-logs" || data.ComponentType.ValueString() != "okta" ||
+				data.Description.ValueString() != "Okta system log" || data.OrganizationID.ValueString() != "org" {
+				t.Errorf("unexpected attributes: %+v", data)
+			}
+
+			got, err := tfDynamicToMapAny(data.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]any{"settings": map[string]any{"org_url": "https://example.okta.com", "rate": int64(25)}}
+			if !dynamicsSemanticallyEqual(got, want) {
+				t.Errorf("expected config %v, got %v", want, got)
+			}
+			if strings.Contains(resp.State.Raw.String(), "do-not-leak") {
+				t.Error("secrets must not be stored in state")
+			}
+		})
+	}
+}
+
+func TestConnectorConfigToTF(t *testing.T) {
+	config, err := connectorConfigToTF(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tfDynamicToMapAny(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings, ok := got["settings"].(map[string]any); !ok || len(settings) != 0 {
+		t.Errorf("expected empty settings, got %v", got)
+	}
+}
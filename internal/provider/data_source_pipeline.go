@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+var _ datasource.DataSource = &DataSourcePipeline{}
+var _ datasource.DataSourceWithConfigure = &DataSourcePipeline{}
+var _ datasource.DataSourceWithValidateConfig = &DataSourcePipeline{}
+
+type DataSourcePipeline struct {
+	client *client.Client
+}
+
+// DataSourcePipelineModel mirrors ResourcePipelineModel, so node slugs and
+// edges read here have the shape monad_pipeline uses.
+type DataSourcePipelineModel struct {
+	ID             types.String           `tfsdk:"id"`
+	Name           types.String           `tfsdk:"name"`
+	Description    types.String           `tfsdk:"description"`
+	Nodes          []ResourcePipelineNode `tfsdk:"nodes"`
+	Edges          []ResourcePipelineEdge `tfsdk:"edges"`
+	Enabled        types.Bool             `tfsdk:"enabled"`
+	OrganizationID types.String           `tfsdk:"organization_id"`
+}
+
+func NewDataSourcePipeline() datasource.DataSource {
+	return &DataSourcePipeline{}
+}
+
+func (d *DataSourcePipeline) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+func (d *DataSourcePipeline) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *ClientData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *DataSourcePipeline) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An existing Monad pipeline, looked up by `id` or by exact `name`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Pipeline identifier. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the pipeline. Matched exactly; more than one pipeline with the name is an error.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the pipeline",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the pipeline is enabled",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization the pipeline belongs to. Defaults to the provider's `organization_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "List of nodes in the pipeline",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"component_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the component",
+							Computed:            true,
+						},
+						"component_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the component",
+							Computed:            true,
+						},
+						"slug": schema.StringAttribute{
+							MarkdownDescription: "Slug for the node",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the node is enabled",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				MarkdownDescription: "List of edges in the pipeline",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Server-assigned identifier of the edge",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the edge",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the edge",
+							Computed:            true,
+						},
+						"from_node_instance_slug": schema.StringAttribute{
+							MarkdownDescription: "Slug of the source node instance",
+							Computed:            true,
+						},
+						"to_node_instance_slug": schema.StringAttribute{
+							MarkdownDescription: "Slug of the target node instance",
+							Computed:            true,
+						},
+						"condition": schema.SingleNestedAttribute{
+							MarkdownDescription: "Conditions for the edge; null for an unconditional edge",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"operator": schema.StringAttribute{
+									MarkdownDescription: "Operator for the condition",
+									Computed:            true,
+								},
+								"conditions": schema.ListNestedAttribute{
+									MarkdownDescription: "Nested conditions for the edge",
+									Computed:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"type_id": schema.StringAttribute{
+												MarkdownDescription: "Type ID for the condition",
+												Computed:            true,
+											},
+											"config": schema.SingleNestedAttribute{
+												MarkdownDescription: "Configuration for the condition",
+												Computed:            true,
+												Attributes: map[string]schema.Attribute{
+													"key": schema.StringAttribute{
+														MarkdownDescription: "The key to check for in the record",
+														Computed:            true,
+													},
+													"value": schema.ListAttribute{
+														MarkdownDescription: "The string values to check for in the record",
+														Computed:            true,
+														ElementType:         types.StringType,
+													},
+													"rate": schema.StringAttribute{
+														MarkdownDescription: "The rate at which records are passed through the condition",
+														Computed:            true,
+													},
+													"threshold": schema.NumberAttribute{
+														MarkdownDescription: "Numeric threshold of the condition",
+														Computed:            true,
+													},
+													"case_sensitive": schema.BoolAttribute{
+														MarkdownDescription: "Whether `value` is compared case-sensitively",
+														Computed:            true,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSourcePipeline) ValidateConfig(
+	ctx context.Context,
+	req datasource.ValidateConfigRequest,
+	resp *datasource.ValidateConfigResponse,
+) {
+	var id, name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || id.IsUnknown() || name.IsUnknown() {
+		return
+	}
+
+	if id.IsNull() == name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid pipeline lookup",
+			"Exactly one of id or name must be set.",
+		)
+	}
+}
+
+func (d *DataSourcePipeline) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data DataSourcePipelineModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(d.client, data.OrganizationID)
+
+	id := data.ID.ValueString()
+	if data.ID.IsNull() {
+		id = d.findPipelineByName(ctx, organizationID, data.Name.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	pipeline, monadResp, err := d.client.GetPipeline(ctx, organizationID, id)
+	if err != nil {
+		addReadError(d.client, &resp.Diagnostics, "pipeline", id, err, monadResp)
+		return
+	}
+
+	data.ID = types.StringPointerValue(pipeline.Id)
+	data.Name = types.StringPointerValue(pipeline.Name)
+	data.Description = stringOrNull(pipeline.Description)
+	data.Enabled = types.BoolValue(pipeline.GetEnabled())
+	data.OrganizationID = types.StringValue(organizationID)
+	data.Nodes = buildPipelineStateNodes(pipeline, nil)
+	data.Edges = buildPipelineStateEdges(pipeline, nil)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findPipelineByName returns the id of the only pipeline in organizationID
+// named name.
+func (d *DataSourcePipeline) findPipelineByName(ctx context.Context, organizationID, name string, diags *diag.Diagnostics) string {
+	pipelines, monadResp, err := d.client.ListPipelines(ctx, organizationID)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list pipelines, got error: %s. Response: %s", err, getResponseBody(d.client, monadResp)),
+		)
+		return ""
+	}
+
+	var ids []string
+	for _, pipeline := range pipelines {
+		if pipeline.GetName() == name {
+			ids = append(ids, pipeline.GetId())
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Pipeline not found",
+			fmt.Sprintf("No pipeline named %q exists in the organization.", name),
+		)
+		return ""
+	case 1:
+		return ids[0]
+	default:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Ambiguous pipeline name",
+			fmt.Sprintf(
+				"%d pipelines are named %q (ids: %s). Look the pipeline up by id instead.",
+				len(ids), name, strings.Join(ids, ", "),
+			),
+		)
+		return ""
+	}
+}
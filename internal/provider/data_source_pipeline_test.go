@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataSourcePipelineRead(t *testing.T) {
+	ctx := context.Background()
+
+	pipelineBody := `{"id": "pipe-1", "name": "security", "enabled": true,
+		"nodes": [
+			{"id": "n-1", "slug": "cloudtrail", "component_type": "input", "component_id": "in-1"},
+			{"id": "n-2", "slug": "siem", "component_type": "output", "component_id": "out-1"}
+		],
+		"edges": [{"id": "e-1", "from_node_instance_id": "n-1", "to_node_instance_id": "n-2"}]}`
+	handler := func(list string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v2/org/pipelines":
+				_, _ = w.Write([]byte(list))
+			case "/api/v2/org/pipelines/pipe-1":
+				_, _ = w.Write([]byte(pipelineBody))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error": "not found"}`))
+			}
+		}
+	}
+	unique := `{"pipelines": [{"id": "pipe-1", "name": "security"}, {"id": "pipe-2", "name": "security-staging"}]}`
+	duplicated := `{"pipelines": [{"id": "pipe-1", "name": "security"}, {"id": "pipe-3", "name": "security"}]}`
+
+	for _, tc := range []struct {
+		name    string
+		lookup  map[string]tftypes.Value
+		list    string
+		wantErr string
+	}{
+		{name: "by id", lookup: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "pipe-1")}, list: unique},
+		{name: "by name", lookup: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "security")}, list: unique},
+		{name: "ambiguous name", lookup: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "security")}, list: duplicated, wantErr: "Ambiguous pipeline name"},
+		{name: "unknown name", lookup: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "audit")}, list: unique, wantErr: "Pipeline not found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &DataSourcePipeline{client: newTestClient(t, handler(tc.list))}
+			s := dataSourceSchema(t, d)
+
+			config := dataSourceObjectValue(s, tc.lookup)
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(config.Type(), nil)}}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+
+			if tc.wantErr != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tc.wantErr {
+					t.Fatalf("expected a %q error, got %s", tc.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var data DataSourcePipelineModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.ID.ValueString() != "pipe-1" || data.Name.ValueString() != "security" || !data.Enabled.ValueBool() {
+				t.Errorf("unexpected pipeline: id %s, name %s, enabled %s", data.ID, data.Name, data.Enabled)
+			}
+			if len(data.Nodes) != 2 || data.Nodes[0].Slug.ValueString() != "cloudtrail" || data.Nodes[1].ComponentID.ValueString() != "out-1" {
+				t.Errorf("unexpected nodes: %+v", data.Nodes)
+			}
+			if len(data.Edges) != 1 || data.Edges[0].FromNodeInstanceSlug.ValueString() != "cloudtrail" ||
+				data.Edges[0].ToNodeInstanceSlug.ValueString() != "siem" || data.Edges[0].Condition != nil {
+				t.Errorf("unexpected edges: %+v", data.Edges)
+			}
+		})
+	}
+}
+
+func TestDataSourcePipelineValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &DataSourcePipeline{}
+	s := dataSourceSchema(t, d)
+
+	for _, tc := range []struct {
+		name    string
+		lookup  map[string]tftypes.Value
+		wantErr bool
+	}{
+		{name: "id", lookup: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "pipe-1")}},
+		{name: "name", lookup: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "security")}},
+		{name: "neither", lookup: map[string]tftypes.Value{}, wantErr: true},
+		{name: "both", lookup: map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "pipe-1"),
+			"name": tftypes.NewValue(tftypes.String, "security"),
+		}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &datasource.ValidateConfigResponse{}
+			d.ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: s, Raw: dataSourceObjectValue(s, tc.lookup)},
+			}, resp)
+			if resp.Diagnostics.HasError() != tc.wantErr {
+				t.Errorf("expected error %t, got %s", tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// dataSourceSchema returns the validated schema of d.
+func dataSourceSchema(t *testing.T, d datasource.DataSource) schema.Schema {
+	t.Helper()
+
+	var resp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	if diags := resp.Schema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("schema invalid: %s", diags)
+	}
+	return resp.Schema
+}
+
+// dataSourceObjectValue builds a raw data source config with the given
+// attributes set and every other attribute null.
+func dataSourceObjectValue(s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	objType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	return tftypes.NewValue(objType, attrs)
+}
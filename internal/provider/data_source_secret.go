@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+var _ datasource.DataSource = &DataSourceSecret{}
+var _ datasource.DataSourceWithConfigure = &DataSourceSecret{}
+
+type DataSourceSecret struct {
+	client *client.Client
+}
+
+// DataSourceSecretModel has no `value`: the API never returns secret values,
+// and this data source exists to reference a secret's metadata without them.
+type DataSourceSecretModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func NewDataSourceSecret() datasource.DataSource {
+	return &DataSourceSecret{}
+}
+
+func (d *DataSourceSecret) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (d *DataSourceSecret) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *ClientData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *DataSourceSecret) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metadata of a Monad secret, looked up by id. The secret value is never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Secret identifier",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the secret",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the secret",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization the secret belongs to. Defaults to the provider's `organization_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceSecret) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data DataSourceSecretModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(d.client, data.OrganizationID)
+
+	secret, monadResp, err := d.client.SecretsAPI.
+		V2OrganizationIdSecretsSecretIdGet(
+			ctx,
+			organizationID,
+			data.ID.ValueString(),
+		).
+		Execute()
+	if err != nil {
+		addReadError(d.client, &resp.Diagnostics, "secret", data.ID.ValueString(), err, monadResp)
+		return
+	}
+
+	data.ID = types.StringPointerValue(secret.Id)
+	data.Name = types.StringPointerValue(secret.Name)
+	data.Description = stringOrNull(secret.Description)
+	data.OrganizationID = types.StringValue(organizationID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
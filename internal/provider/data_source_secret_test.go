@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataSourceSecretReadByID(t *testing.T) {
+	ctx := context.Background()
+
+	var gotPath string
+	d := &DataSourceSecret{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		// Even if the API were to echo a value, it must not reach state.
+		_, _ = w.Write([]byte(`{"id": "sec-1", "name": "splunk-token", "description": "HEC token", "value": "do-not-leak"}`))
+	})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	if diags := s.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("schema invalid: %s", diags)
+	}
+	if _, ok := s.Attributes["value"]; ok {
+		t.Fatal("the data source must not have a value attribute")
+	}
+
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	config := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "sec-1"),
+		"name":            tftypes.NewValue(tftypes.String, nil),
+		"description":     tftypes.NewValue(tftypes.String, nil),
+		"organization_id": tftypes.NewValue(tftypes.String, nil),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objType, nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+	}
+
+	if gotPath != "/api/v2/org/secrets/sec-1" {
+		t.Errorf("expected a lookup by id, got %s", gotPath)
+	}
+
+	var data DataSourceSecretModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Name.ValueString() != "splunk-token" || data.Description.ValueString() != "HEC token" {
+		t.Errorf("unexpected metadata: %+v", data)
+	}
+	if data.OrganizationID.ValueString() != "org" {
+		t.Errorf("expected the provider organization, got %s", data.OrganizationID)
+	}
+	if strings.Contains(resp.State.Raw.String(), "do-not-leak") {
+		t.Error("the secret value must not be stored in state")
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	monad "github.com/monad-inc/sdk/go"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+var _ datasource.DataSource = &DataSourceTransform{}
+var _ datasource.DataSourceWithConfigure = &DataSourceTransform{}
+
+type DataSourceTransform struct {
+	client *client.Client
+}
+
+type DataSourceTransformModel struct {
+	Operation      types.String `tfsdk:"operation"`
+	IDs            types.List   `tfsdk:"ids"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func NewDataSourceTransform() datasource.DataSource {
+	return &DataSourceTransform{}
+}
+
+func (d *DataSourceTransform) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_transform"
+}
+
+func (d *DataSourceTransform) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *ClientData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *DataSourceTransform) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Finds the transforms whose config contains a given operation.",
+
+		Attributes: map[string]schema.Attribute{
+			"operation": schema.StringAttribute{
+				MarkdownDescription: "Operation name to look for in each transform's `operations`, e.g. `drop_key`",
+				Required:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "Identifiers of the transforms containing the operation, in API order. Empty when none match.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization to search. Defaults to the provider's `organization_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceTransform) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data DataSourceTransformModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(d.client, data.OrganizationID)
+
+	transforms, monadResp, err := d.client.ListTransforms(ctx, organizationID)
+	if err != nil {
+		addClientError(d.client, &resp.Diagnostics, "list transforms", err, monadResp)
+		return
+	}
+
+	ids := []string{}
+	for _, transform := range transforms {
+		found, err := transformHasOperation(transform, data.Operation.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to convert transform config",
+				fmt.Sprintf("Transform %q: %s", transform.GetId(), err),
+			)
+			return
+		}
+		if found {
+			ids = append(ids, transform.GetId())
+		}
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.IDs = idList
+	data.OrganizationID = types.StringValue(organizationID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// transformHasOperation reports whether one of transform's operations is named
+// operation.
+func transformHasOperation(transform monad.ModelsTransform, operation string) (bool, error) {
+	config, err := transformConfigToMap(transform.Config, nil)
+	if err != nil {
+		return false, err
+	}
+
+	operations, _ := config["operations"].([]any)
+	for _, op := range operations {
+		if m, ok := op.(map[string]any); ok && m["operation"] == operation {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataSourceTransformFindsOperation(t *testing.T) {
+	ctx := context.Background()
+
+	d := &DataSourceTransform{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/org/transforms" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"transforms": [
+			{"id": "tr-1", "name": "redact", "config": {"operations": [
+				{"operation": "rename_key", "arguments": {"from": "a", "to": "b"}},
+				{"operation": "drop_key", "arguments": {"key": "password"}}
+			]}},
+			{"id": "tr-2", "name": "rename", "config": {"operations": [
+				{"operation": "rename_key", "arguments": {"from": "c", "to": "d"}}
+			]}},
+			{"id": "tr-3", "name": "passthrough"},
+			{"id": "tr-4", "name": "strip", "config": {"operations": [
+				{"operation": "drop_key", "arguments": {"key": "token"}}
+			]}}
+		]}`))
+	})}
+	s := dataSourceSchema(t, d)
+
+	for _, tc := range []struct {
+		operation string
+		want      []string
+	}{
+		{operation: "drop_key", want: []string{"tr-1", "tr-4"}},
+		{operation: "rename_key", want: []string{"tr-1", "tr-2"}},
+		{operation: "flatten", want: []string{}},
+	} {
+		t.Run(tc.operation, func(t *testing.T) {
+			config := dataSourceObjectValue(s, map[string]tftypes.Value{
+				"operation": tftypes.NewValue(tftypes.String, tc.operation),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(config.Type(), nil)}}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var data DataSourceTransformModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			var ids []string
+			resp.Diagnostics.Append(data.IDs.ElementsAs(ctx, &ids, false)...)
+			if len(ids) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, ids)
+			}
+			for i := range ids {
+				if ids[i] != tc.want[i] {
+					t.Errorf("expected %v, got %v", tc.want, ids)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	monad "github.com/monad-inc/sdk/go"
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+var _ ephemeral.EphemeralResource = &EphemeralSecret{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EphemeralSecret{}
+
+// EphemeralSecret writes a secret value to Monad without recording it in the
+// plan or state. Unlike ResourceSecret it leaves nothing in state at all: the
+// secret is looked up by name on every run, so its `id` can feed the
+// write-only `config.secrets` of a connector.
+type EphemeralSecret struct {
+	client *client.Client
+}
+
+type EphemeralSecretModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Value          types.String `tfsdk:"value"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func NewEphemeralSecret() ephemeral.EphemeralResource {
+	return &EphemeralSecret{}
+}
+
+func (e *EphemeralSecret) Metadata(
+	ctx context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (e *EphemeralSecret) Configure(
+	ctx context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *ClientData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	e.client = clientData
+}
+
+func (e *EphemeralSecret) Schema(
+	ctx context.Context,
+	req ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Monad secret whose value is written on every plan and apply but never stored in the Terraform plan or state. The secret named `name` is updated with `value`, or created when the organization has none. The secret is not deleted when Terraform finishes, so connectors can keep referencing its `id`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Secret identifier, for use as `{ id = id }` in a connector's `config.secrets`",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the secret. Matched exactly; more than one secret with the name is an error.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the secret. Defaults to the provider's `default_description` when the secret is created.",
+				Optional:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the secret",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization the secret belongs to. Defaults to the provider's `organization_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *EphemeralSecret) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var data EphemeralSecretModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(e.client, data.OrganizationID)
+
+	id, found := e.findSecretByName(ctx, organizationID, data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := monad.RoutesV2CreateOrUpdateSecretRequest{
+		Name:        data.Name.ValueStringPointer(),
+		Description: data.Description.ValueStringPointer(),
+		Value:       data.Value.ValueStringPointer(),
+	}
+
+	var secret *monad.RoutesV2SecretResponse
+	if found {
+		updated, monadResp, err := e.client.UpdateSecret(ctx, organizationID, id, request)
+		if err != nil {
+			addClientError(e.client, &resp.Diagnostics, "update secret", err, monadResp)
+			return
+		}
+		secret = updated
+		tflog.Trace(ctx, "updated a secret for an ephemeral secret")
+	} else {
+		if request.Description == nil && e.client.DefaultDescription != "" {
+			request.Description = &e.client.DefaultDescription
+		}
+		created, monadResp, err := e.client.CreateSecret(ctx, organizationID, request)
+		if err != nil {
+			addClientError(e.client, &resp.Diagnostics, "create secret", err, monadResp)
+			return
+		}
+		secret = created
+		tflog.Trace(ctx, "created a secret for an ephemeral secret")
+	}
+
+	data.ID = types.StringPointerValue(secret.Id)
+	data.OrganizationID = types.StringValue(organizationID)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// findSecretByName returns the id of the only secret in organizationID named
+// name, and whether one exists.
+func (e *EphemeralSecret) findSecretByName(ctx context.Context, organizationID, name string, diags *diag.Diagnostics) (string, bool) {
+	secrets, monadResp, err := e.client.ListSecrets(ctx, organizationID)
+	if err != nil {
+		addClientError(e.client, diags, "list secrets", err, monadResp)
+		return "", false
+	}
+
+	var ids []string
+	for _, secret := range secrets {
+		if secret.GetName() == name {
+			ids = append(ids, secret.GetId())
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", false
+	case 1:
+		return ids[0], true
+	default:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Ambiguous secret name",
+			fmt.Sprintf(
+				"%d secrets are named %q (ids: %s). Rename or delete the duplicates.",
+				len(ids), name, strings.Join(ids, ", "),
+			),
+		)
+		return "", false
+	}
+}
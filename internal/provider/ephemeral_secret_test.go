@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEphemeralSecretOpen(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		existing    string
+		description any
+		wantMethod  string
+		wantPath    string
+		wantDesc    string
+		wantID      string
+		wantErr     string
+	}{
+		"creates a missing secret": {
+			existing:   `[{"id": "sec-other", "name": "other"}]`,
+			wantMethod: http.MethodPost,
+			wantPath:   "/api/v2/org/secrets",
+			wantDesc:   "Managed by Terraform",
+			wantID:     "sec-new",
+		},
+		"updates an existing secret": {
+			existing:    `[{"id": "sec-1", "name": "splunk-token"}]`,
+			description: "HEC token",
+			wantMethod:  http.MethodPatch,
+			wantPath:    "/api/v2/org/secrets/sec-1",
+			wantDesc:    "HEC token",
+			wantID:      "sec-1",
+		},
+		"rejects an ambiguous name": {
+			existing: `[{"id": "sec-1", "name": "splunk-token"}, {"id": "sec-2", "name": "splunk-token"}]`,
+			wantErr:  "Ambiguous secret name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotMethod, gotPath string
+			var gotBody map[string]any
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"secrets": ` + tt.existing + `}`))
+					return
+				}
+				gotMethod, gotPath = r.Method, r.URL.Path
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+				id := "sec-new"
+				if r.Method == http.MethodPatch {
+					id = "sec-1"
+				}
+				_, _ = w.Write([]byte(`{"id": "` + id + `", "name": "splunk-token"}`))
+			})
+			c.DefaultDescription = "Managed by Terraform"
+			e := &EphemeralSecret{client: c}
+
+			var schemaResp ephemeral.SchemaResponse
+			e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+			s := schemaResp.Schema
+			if diags := s.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("schema invalid: %s", diags)
+			}
+
+			objType := s.Type().TerraformType(ctx).(tftypes.Object)
+			config := tftypes.NewValue(objType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, nil),
+				"name":            tftypes.NewValue(tftypes.String, "splunk-token"),
+				"description":     tftypes.NewValue(tftypes.String, tt.description),
+				"value":           tftypes.NewValue(tftypes.String, "hunter2"),
+				"organization_id": tftypes.NewValue(tftypes.String, nil),
+			})
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s, Raw: config}}
+			e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+
+			if tt.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), tt.wantErr) {
+					t.Fatalf("expected %q, got %s", tt.wantErr, resp.Diagnostics)
+				}
+				if gotMethod != "" {
+					t.Errorf("expected no write, got %s %s", gotMethod, gotPath)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			if gotMethod != tt.wantMethod || gotPath != tt.wantPath {
+				t.Errorf("expected %s %s, got %s %s", tt.wantMethod, tt.wantPath, gotMethod, gotPath)
+			}
+			if gotBody["value"] != "hunter2" || gotBody["description"] != tt.wantDesc {
+				t.Errorf("unexpected request body: %v", gotBody)
+			}
+
+			var data EphemeralSecretModel
+			resp.Diagnostics.Append(resp.Result.Get(ctx, &data)...)
+			if data.ID.ValueString() != tt.wantID {
+				t.Errorf("expected id %s, got %s", tt.wantID, data.ID)
+			}
+			if data.OrganizationID.ValueString() != "org" {
+				t.Errorf("expected the provider organization, got %s", data.OrganizationID)
+			}
+		})
+	}
+}
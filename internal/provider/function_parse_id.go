@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &FunctionParseID{}
+
+// FunctionParseID splits a Monad resource URL or URN into its organization,
+// resource type and resource id.
+type FunctionParseID struct{}
+
+// parsedID is the object returned by parse_id.
+type parsedID struct {
+	OrgID        string `tfsdk:"org_id"`
+	ResourceType string `tfsdk:"resource_type"`
+	ResourceID   string `tfsdk:"resource_id"`
+}
+
+var parsedIDAttributeTypes = map[string]attr.Type{
+	"org_id":        types.StringType,
+	"resource_type": types.StringType,
+	"resource_id":   types.StringType,
+}
+
+// monadResourceTypes maps the collection names used in URLs (and accepted in
+// URNs) to the singular resource type parse_id returns.
+var monadResourceTypes = map[string]string{
+	"inputs":      "input",
+	"outputs":     "output",
+	"enrichments": "enrichment",
+	"transforms":  "transform",
+	"pipelines":   "pipeline",
+	"secrets":     "secret",
+}
+
+var apiVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+func NewFunctionParseID() function.Function {
+	return &FunctionParseID{}
+}
+
+func (f *FunctionParseID) Metadata(
+	ctx context.Context,
+	req function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_id"
+}
+
+func (f *FunctionParseID) Definition(
+	ctx context.Context,
+	req function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a Monad resource URL or URN",
+		MarkdownDescription: "Extracts `org_id`, `resource_type` and `resource_id` from a Monad UI or API " +
+			"URL (e.g. `https://app.monad.com/{org_id}/inputs/{id}`) or a URN " +
+			"(`urn:monad:{org_id}:{resource_type}:{id}`). `resource_type` is one of `input`, " +
+			"`output`, `enrichment`, `transform`, `pipeline` or `secret`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url_or_urn",
+				MarkdownDescription: "Resource URL or URN to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedIDAttributeTypes,
+		},
+	}
+}
+
+func (f *FunctionParseID) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var input string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseMonadID(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsed))
+}
+
+// parseMonadID parses a URN of the form urn:monad:{org}:{type}:{id}, or a URL
+// whose path (or fragment, for hash-routed UI links) contains
+// {org}/{collection}/{id}, optionally behind an api/vN prefix.
+func parseMonadID(s string) (parsedID, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		parts := strings.Split(s, ":")
+		if len(parts) != 5 || !strings.EqualFold(parts[1], "monad") {
+			return parsedID{}, fmt.Errorf("%q is not a Monad URN; expected urn:monad:{org_id}:{resource_type}:{id}", s)
+		}
+		resourceType, ok := resourceTypeOf(parts[3])
+		if !ok {
+			return parsedID{}, fmt.Errorf("%q has unrecognized resource type %q", s, parts[3])
+		}
+		if parts[2] == "" || parts[4] == "" {
+			return parsedID{}, fmt.Errorf("%q is missing the organization or resource id", s)
+		}
+		return parsedID{OrgID: parts[2], ResourceType: resourceType, ResourceID: parts[4]}, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return parsedID{}, fmt.Errorf("%q is neither an http(s) URL nor a Monad URN", s)
+	}
+
+	segments := splitPath(u.Path)
+	segments = append(segments, splitPath(u.Fragment)...)
+	for i := 1; i+1 < len(segments); i++ {
+		resourceType, ok := monadResourceTypes[segments[i]]
+		if !ok {
+			continue
+		}
+		org := segments[i-1]
+		if org == "api" || apiVersionSegment.MatchString(org) {
+			continue
+		}
+		return parsedID{OrgID: org, ResourceType: resourceType, ResourceID: segments[i+1]}, nil
+	}
+
+	return parsedID{}, fmt.Errorf(
+		"%q does not contain {org_id}/{collection}/{id}, where collection is one of inputs, outputs, "+
+			"enrichments, transforms, pipelines or secrets",
+		s,
+	)
+}
+
+// resourceTypeOf accepts a resource type in singular or plural form.
+func resourceTypeOf(s string) (string, bool) {
+	s = strings.ToLower(s)
+	if t, ok := monadResourceTypes[s]; ok {
+		return t, true
+	}
+	if _, ok := monadResourceTypes[s+"s"]; ok {
+		return s, true
+	}
+	return "", false
+}
+
+func splitPath(p string) []string {
+	var segments []string
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
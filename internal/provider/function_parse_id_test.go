@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionParseID(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		input string
+		want  parsedID
+	}{
+		"ui url": {
+			input: "https://app.monad.com/org-1/inputs/in-1",
+			want:  parsedID{OrgID: "org-1", ResourceType: "input", ResourceID: "in-1"},
+		},
+		"ui url with trailing page": {
+			input: "https://app.monad.com/org-1/pipelines/pl-1/edit?tab=nodes",
+			want:  parsedID{OrgID: "org-1", ResourceType: "pipeline", ResourceID: "pl-1"},
+		},
+		"hash routed ui url": {
+			input: "https://app.monad.com/#/org-1/outputs/out-1",
+			want:  parsedID{OrgID: "org-1", ResourceType: "output", ResourceID: "out-1"},
+		},
+		"api url": {
+			input: "https://monad.com/api/v3/org-1/enrichments/en-1",
+			want:  parsedID{OrgID: "org-1", ResourceType: "enrichment", ResourceID: "en-1"},
+		},
+		"urn": {
+			input: "urn:monad:org-1:secret:sec-1",
+			want:  parsedID{OrgID: "org-1", ResourceType: "secret", ResourceID: "sec-1"},
+		},
+		"urn with plural type": {
+			input: "urn:monad:org-1:transforms:tr-1",
+			want:  parsedID{OrgID: "org-1", ResourceType: "transform", ResourceID: "tr-1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := runParseID(ctx, tc.input)
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			want := types.ObjectValueMust(parsedIDAttributeTypes, map[string]attr.Value{
+				"org_id":        types.StringValue(tc.want.OrgID),
+				"resource_type": types.StringValue(tc.want.ResourceType),
+				"resource_id":   types.StringValue(tc.want.ResourceID),
+			})
+			if !resp.Result.Value().Equal(want) {
+				t.Errorf("expected %s, got %s", want, resp.Result.Value())
+			}
+		})
+	}
+
+	for _, input := range []string{
+		"in-1",
+		"https://app.monad.com/org-1/dashboards/d-1",
+		"https://monad.com/api/v2/inputs/in-1",
+		"urn:aws:org-1:input:in-1",
+		"urn:monad:org-1:widget:w-1",
+	} {
+		resp := runParseID(ctx, input)
+		if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+			t.Errorf("%q: expected an argument error, got %v", input, resp.Error)
+			continue
+		}
+		if !strings.Contains(resp.Error.Text, input) {
+			t.Errorf("%q: expected the error to quote the input, got %s", input, resp.Error.Text)
+		}
+	}
+}
+
+func runParseID(ctx context.Context, input string) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(parsedIDAttributeTypes))}
+	NewFunctionParseID().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)}),
+	}, resp)
+	return resp
+}
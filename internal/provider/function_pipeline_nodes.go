@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &FunctionPipelineNodes{}
+
+// FunctionPipelineNodes builds the `nodes` of a monad_pipeline from a map of
+// slugs to components.
+type FunctionPipelineNodes struct{}
+
+// pipelineNodeComponent is one entry of the pipeline_nodes argument.
+type pipelineNodeComponent struct {
+	ComponentType string `tfsdk:"component_type"`
+	ComponentID   string `tfsdk:"component_id"`
+}
+
+var pipelineNodeComponentAttributeTypes = map[string]attr.Type{
+	"component_type": types.StringType,
+	"component_id":   types.StringType,
+}
+
+// pipelineNodeAttributeTypes is the object type of ResourcePipelineNode.
+var pipelineNodeAttributeTypes = map[string]attr.Type{
+	"component_type": types.StringType,
+	"component_id":   types.StringType,
+	"slug":           types.StringType,
+	"enabled":        types.BoolType,
+}
+
+func NewFunctionPipelineNodes() function.Function {
+	return &FunctionPipelineNodes{}
+}
+
+func (f *FunctionPipelineNodes) Metadata(
+	ctx context.Context,
+	req function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "pipeline_nodes"
+}
+
+func (f *FunctionPipelineNodes) Definition(
+	ctx context.Context,
+	req function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Build the nodes of a pipeline",
+		MarkdownDescription: "Turns a map of node slugs to `{ component_type, component_id }` objects into " +
+			"the list of `{ component_type, component_id, slug, enabled }` objects used by the `nodes` blocks of " +
+			"`monad_pipeline`, sorted by slug, with `enabled` left null. Use it with a `dynamic \"nodes\"` block.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "components",
+				MarkdownDescription: "Map of node slug to an object with the node's `component_type` and `component_id`",
+				ElementType:         types.ObjectType{AttrTypes: pipelineNodeComponentAttributeTypes},
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: pipelineNodeAttributeTypes},
+		},
+	}
+}
+
+func (f *FunctionPipelineNodes) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var components map[string]pipelineNodeComponent
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &components))
+	if resp.Error != nil {
+		return
+	}
+
+	nodes, err := pipelineNodes(components)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, nodes))
+}
+
+// pipelineNodes returns one node per entry of components, in slug order so the
+// result is stable across plans.
+func pipelineNodes(components map[string]pipelineNodeComponent) ([]ResourcePipelineNode, error) {
+	nodes := make([]ResourcePipelineNode, 0, len(components))
+	for _, slug := range slices.Sorted(maps.Keys(components)) {
+		component := components[slug]
+		if slug == "" {
+			return nil, fmt.Errorf("node slugs must not be empty")
+		}
+		if component.ComponentType == "" || component.ComponentID == "" {
+			return nil, fmt.Errorf("node %q must set both component_type and component_id", slug)
+		}
+		nodes = append(nodes, ResourcePipelineNode{
+			ComponentType: types.StringValue(component.ComponentType),
+			ComponentID:   types.StringValue(component.ComponentID),
+			Slug:          types.StringValue(slug),
+			Enabled:       types.BoolNull(),
+		})
+	}
+	return nodes, nil
+}
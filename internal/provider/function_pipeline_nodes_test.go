@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionPipelineNodes(t *testing.T) {
+	ctx := context.Background()
+	componentType := types.ObjectType{AttrTypes: pipelineNodeComponentAttributeTypes}
+	nodeType := types.ObjectType{AttrTypes: pipelineNodeAttributeTypes}
+
+	component := func(componentType, componentID string) attr.Value {
+		return types.ObjectValueMust(pipelineNodeComponentAttributeTypes, map[string]attr.Value{
+			"component_type": types.StringValue(componentType),
+			"component_id":   types.StringValue(componentID),
+		})
+	}
+	run := func(components map[string]attr.Value) *function.RunResponse {
+		resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(nodeType))}
+		NewFunctionPipelineNodes().Run(ctx, function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.MapValueMust(componentType, components)}),
+		}, resp)
+		return resp
+	}
+
+	resp := run(map[string]attr.Value{
+		"siem":       component("output", "out-1"),
+		"cloudtrail": component("input", "in-1"),
+		"redact":     component("transform", "tr-1"),
+	})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	node := func(componentType, componentID, slug string) attr.Value {
+		return types.ObjectValueMust(pipelineNodeAttributeTypes, map[string]attr.Value{
+			"component_type": types.StringValue(componentType),
+			"component_id":   types.StringValue(componentID),
+			"slug":           types.StringValue(slug),
+			"enabled":        types.BoolNull(),
+		})
+	}
+	want := types.ListValueMust(nodeType, []attr.Value{
+		node("input", "in-1", "cloudtrail"),
+		node("transform", "tr-1", "redact"),
+		node("output", "out-1", "siem"),
+	})
+	if !resp.Result.Value().Equal(want) {
+		t.Errorf("expected %s, got %s", want, resp.Result.Value())
+	}
+
+	// The result decodes into the monad_pipeline node model.
+	var nodes []ResourcePipelineNode
+	if diags := resp.Result.Value().(types.List).ElementsAs(ctx, &nodes, false); diags.HasError() {
+		t.Fatalf("result does not match ResourcePipelineNode: %s", diags)
+	}
+
+	resp = run(map[string]attr.Value{"siem": component("output", "")})
+	if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+		t.Errorf("expected an argument error for a node without component_id, got %v", resp.Error)
+	}
+
+	resp = run(map[string]attr.Value{})
+	if resp.Error != nil || len(resp.Result.Value().(types.List).Elements()) != 0 {
+		t.Errorf("expected an empty list, got %s (error %v)", resp.Result.Value(), resp.Error)
+	}
+}
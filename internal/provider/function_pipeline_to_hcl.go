@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &FunctionPipelineToHCL{}
+
+// FunctionPipelineToHCL renders a pipeline read by the monad_pipeline data
+// source as the configuration of an equivalent monad_pipeline resource.
+type FunctionPipelineToHCL struct{}
+
+func NewFunctionPipelineToHCL() function.Function {
+	return &FunctionPipelineToHCL{}
+}
+
+func (f *FunctionPipelineToHCL) Metadata(
+	ctx context.Context,
+	req function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "pipeline_to_hcl"
+}
+
+func (f *FunctionPipelineToHCL) Definition(
+	ctx context.Context,
+	req function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Render a pipeline as monad_pipeline configuration",
+		MarkdownDescription: "Returns a formatted `resource \"monad_pipeline\"` block reproducing the name, " +
+			"description, `enabled`, nodes, edges and edge conditions of a pipeline read with the " +
+			"`monad_pipeline` data source, e.g. `provider::monad::pipeline_to_hcl(data.monad_pipeline.x)`. " +
+			"The resource is labelled after the pipeline name. Computed values such as edge ids and " +
+			"`organization_id` are left out.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "pipeline",
+				MarkdownDescription: "A `monad_pipeline` data source",
+				AttributeTypes:      pipelineDataSourceAttributeTypes(ctx),
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FunctionPipelineToHCL) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var pipeline DataSourcePipelineModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pipeline))
+	if resp.Error != nil {
+		return
+	}
+
+	if pipeline.Name.ValueString() == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the pipeline must have a name")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, pipelineToHCL(pipeline)))
+}
+
+// pipelineDataSourceAttributeTypes is the object type of the monad_pipeline
+// data source, so the function accepts `data.monad_pipeline.x` as is.
+func pipelineDataSourceAttributeTypes(ctx context.Context) map[string]attr.Type {
+	var resp datasource.SchemaResponse
+	(&DataSourcePipeline{}).Schema(ctx, datasource.SchemaRequest{}, &resp)
+	return resp.Schema.Type().(types.ObjectType).AttrTypes
+}
+
+// hclBlock is a block of generated configuration: its attributes, already
+// rendered as HCL expressions, followed by its nested blocks.
+type hclBlock struct {
+	header string
+	attrs  []hclAttribute
+	blocks []hclBlock
+}
+
+type hclAttribute struct {
+	name, expr string
+}
+
+// attr appends the attribute name when value is set.
+func (b *hclBlock) attr(name string, value attr.Value) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	var expr string
+	switch v := value.(type) {
+	case types.String:
+		expr = hclString(v.ValueString())
+	case types.Bool:
+		expr = strconv.FormatBool(v.ValueBool())
+	case types.Number:
+		expr = v.ValueBigFloat().Text('g', -1)
+		if v.ValueBigFloat().IsInt() {
+			expr = v.ValueBigFloat().Text('f', 0)
+		}
+	case types.List:
+		items := make([]string, 0, len(v.Elements()))
+		for _, element := range v.Elements() {
+			if s, ok := element.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+				items = append(items, hclString(s.ValueString()))
+			}
+		}
+		expr = "[" + strings.Join(items, ", ") + "]"
+	default:
+		expr = value.String()
+	}
+	b.attrs = append(b.attrs, hclAttribute{name: name, expr: expr})
+}
+
+// render writes the block the way `terraform fmt` would: the `=` of its
+// attributes aligned, and a blank line before every nested block.
+func (b hclBlock) render(w *strings.Builder, indent string) {
+	if len(b.attrs) == 0 && len(b.blocks) == 0 {
+		fmt.Fprintf(w, "%s%s {}\n", indent, b.header)
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s {\n", indent, b.header)
+	width := 0
+	for _, a := range b.attrs {
+		width = max(width, len(a.name))
+	}
+	for _, a := range b.attrs {
+		fmt.Fprintf(w, "%s  %-*s = %s\n", indent, width, a.name, a.expr)
+	}
+	for i, block := range b.blocks {
+		if i > 0 || len(b.attrs) > 0 {
+			w.WriteString("\n")
+		}
+		block.render(w, indent+"  ")
+	}
+	fmt.Fprintf(w, "%s}\n", indent)
+}
+
+// pipelineToHCL renders pipeline as a monad_pipeline resource block.
+func pipelineToHCL(pipeline DataSourcePipelineModel) string {
+	resource := hclBlock{
+		header: fmt.Sprintf("resource \"monad_pipeline\" %s", hclString(pipelineResourceLabel(pipeline.Name.ValueString()))),
+	}
+	resource.attr("name", pipeline.Name)
+	resource.attr("description", pipeline.Description)
+	resource.attr("enabled", pipeline.Enabled)
+
+	for _, node := range pipeline.Nodes {
+		block := hclBlock{header: "nodes"}
+		block.attr("component_type", node.ComponentType)
+		block.attr("component_id", node.ComponentID)
+		block.attr("slug", node.Slug)
+		// Nodes are enabled by default, so only a disabled node says so.
+		if !node.Enabled.IsNull() && !node.Enabled.IsUnknown() && !node.Enabled.ValueBool() {
+			block.attr("enabled", node.Enabled)
+		}
+		resource.blocks = append(resource.blocks, block)
+	}
+
+	for _, edge := range pipeline.Edges {
+		block := hclBlock{header: "edges"}
+		block.attr("name", edge.Name)
+		block.attr("description", edge.Description)
+		block.attr("from_node_instance_slug", edge.FromNodeInstanceSlug)
+		block.attr("to_node_instance_slug", edge.ToNodeInstanceSlug)
+		if edge.Condition != nil {
+			block.blocks = append(block.blocks, pipelineConditionToHCL(edge.Condition))
+		}
+		resource.blocks = append(resource.blocks, block)
+	}
+
+	var w strings.Builder
+	resource.render(&w, "")
+	return w.String()
+}
+
+func pipelineConditionToHCL(condition *ResourcePipelineCondition) hclBlock {
+	block := hclBlock{header: "condition"}
+	block.attr("operator", condition.Operator)
+	for _, c := range condition.Conditions {
+		nested := hclBlock{header: "conditions"}
+		nested.attr("type_id", c.TypeID)
+
+		config := hclBlock{header: "config"}
+		config.attr("key", c.Config.Key)
+		config.attr("value", c.Config.Value)
+		config.attr("rate", c.Config.Rate)
+		config.attr("threshold", c.Config.Threshold)
+		config.attr("case_sensitive", c.Config.CaseSensitive)
+		nested.blocks = append(nested.blocks, config)
+
+		block.blocks = append(block.blocks, nested)
+	}
+	return block
+}
+
+var nonIdentifierRun = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// pipelineResourceLabel turns a pipeline name into a resource label: lower
+// case, with every run of other characters replaced by an underscore.
+func pipelineResourceLabel(name string) string {
+	label := strings.Trim(nonIdentifierRun.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "pipeline_" + label
+	}
+	return strings.TrimSuffix(label, "_")
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences so
+// the value is taken literally.
+func hclString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionPipelineToHCL(t *testing.T) {
+	ctx := context.Background()
+
+	pipeline, diags := types.ObjectValueFrom(ctx, pipelineDataSourceAttributeTypes(ctx), DataSourcePipelineModel{
+		ID:          types.StringValue("pipe-1"),
+		Name:        types.StringValue("Security Logs (prod)"),
+		Description: types.StringValue("Routes ${env} audit logs"),
+		Enabled:     types.BoolValue(true),
+		Nodes: []ResourcePipelineNode{
+			{ComponentType: types.StringValue("input"), ComponentID: types.StringValue("in-1"), Slug: types.StringValue("cloudtrail")},
+			{ComponentType: types.StringValue("output"), ComponentID: types.StringValue("out-1"), Slug: types.StringValue("siem")},
+		},
+		Edges: []ResourcePipelineEdge{{
+			ID:                   types.StringValue("edge-1"),
+			Name:                 types.StringNull(),
+			Description:          types.StringNull(),
+			FromNodeInstanceSlug: types.StringValue("cloudtrail"),
+			ToNodeInstanceSlug:   types.StringValue("siem"),
+			Condition: &ResourcePipelineCondition{
+				Operator: types.StringValue("and"),
+				Conditions: []ResourcePipelineConditionCondition{{
+					TypeID: types.StringValue("key_has_value"),
+					Config: ResourcePipelineConditionConditionConfig{
+						Key:           types.StringValue("eventSource"),
+						Value:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("iam.amazonaws.com"), types.StringValue("sts.amazonaws.com")}),
+						Rate:          types.StringNull(),
+						CaseSensitive: types.BoolValue(false),
+					},
+				}},
+			},
+		}},
+		OrganizationID: types.StringValue("org"),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewFunctionPipelineToHCL().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{pipeline}),
+	}, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	want := `resource "monad_pipeline" "security_logs_prod" {
+  name        = "Security Logs (prod)"
+  description = "Routes $${env} audit logs"
+  enabled     = true
+
+  nodes {
+    component_type = "input"
+    component_id   = "in-1"
+    slug           = "cloudtrail"
+  }
+
+  nodes {
+    component_type = "output"
+    component_id   = "out-1"
+    slug           = "siem"
+  }
+
+  edges {
+    from_node_instance_slug = "cloudtrail"
+    to_node_instance_slug   = "siem"
+
+    condition {
+      operator = "and"
+
+      conditions {
+        type_id = "key_has_value"
+
+        config {
+          key            = "eventSource"
+          value          = ["iam.amazonaws.com", "sts.amazonaws.com"]
+          case_sensitive = false
+        }
+      }
+    }
+  }
+}
+`
+	if got := resp.Result.Value().(types.String).ValueString(); got != want {
+		t.Errorf("unexpected HCL:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPipelineResourceLabel(t *testing.T) {
+	for name, want := range map[string]string{
+		"security":       "security",
+		"Security Logs":  "security_logs",
+		"--edge--case--": "edge_case",
+		"2024 audit":     "pipeline_2024_audit",
+		"日本":             "pipeline",
+	} {
+		if got := pipelineResourceLabel(name); got != want {
+			t.Errorf("%q: expected %q, got %q", name, want, got)
+		}
+	}
+}
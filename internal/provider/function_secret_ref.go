@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &FunctionSecretRef{}
+
+// FunctionSecretRef builds the `{ id = id }` object that references an
+// existing Monad secret from a connector's secrets.
+type FunctionSecretRef struct{}
+
+var secretRefAttributeTypes = map[string]attr.Type{
+	secretIDKey: types.StringType,
+}
+
+func NewFunctionSecretRef() function.Function {
+	return &FunctionSecretRef{}
+}
+
+func (f *FunctionSecretRef) Metadata(
+	ctx context.Context,
+	req function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "secret_ref"
+}
+
+func (f *FunctionSecretRef) Definition(
+	ctx context.Context,
+	req function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Reference a Monad secret",
+		MarkdownDescription: "Returns the `{ id = id }` object that references the existing secret `id`, for use " +
+			"as a value in a connector's `config.secrets`, e.g. " +
+			"`secrets = { api_key = provider::monad::secret_ref(monad_secret.x.id) }`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "Identifier of the secret to reference",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: secretRefAttributeTypes,
+		},
+	}
+}
+
+func (f *FunctionSecretRef) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.TrimSpace(id) == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the secret id must not be empty")
+		return
+	}
+
+	ref, diags := types.ObjectValue(secretRefAttributeTypes, map[string]attr.Value{
+		secretIDKey: types.StringValue(id),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, ref))
+}
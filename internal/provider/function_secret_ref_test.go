@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionSecretRef(t *testing.T) {
+	ctx := context.Background()
+
+	resp := runSecretRef(ctx, "sec-1")
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	want := types.ObjectValueMust(secretRefAttributeTypes, map[string]attr.Value{
+		"id": types.StringValue("sec-1"),
+	})
+	if !resp.Result.Value().Equal(want) {
+		t.Errorf("expected %s, got %s", want, resp.Result.Value())
+	}
+
+	// The result is a reference connector secrets accept.
+	var diags diag.Diagnostics
+	checkSecretValue(&diags, "config.secrets.api_key", resp.Result.Value())
+	if diags.HasError() {
+		t.Errorf("expected a valid secret reference, got %s", diags)
+	}
+
+	for _, id := range []string{"", "  "} {
+		resp := runSecretRef(ctx, id)
+		if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+			t.Errorf("%q: expected an argument error, got %v", id, resp.Error)
+		}
+	}
+}
+
+func runSecretRef(ctx context.Context, id string) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(secretRefAttributeTypes))}
+	NewFunctionSecretRef().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(id)}),
+	}, resp)
+	return resp
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+// resourceSchema returns the schema of r, failing the test on diagnostics.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema build failed: %s", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// schemaObjectValue builds a raw object value for s from the given top-level
+// attribute values; every attribute or block not listed is null.
+func schemaObjectValue(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objType, ok := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatalf("schema type is not an object")
+	}
+
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	return tftypes.NewValue(objType, attrs)
+}
+
+// nullSchemaObjectValue is the raw value of absent state (a create) or an
+// absent plan (a destroy).
+func nullSchemaObjectValue(s schema.Schema) tftypes.Value {
+	return tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)
+}
+
+// newModifyPlanRequest assembles a ModifyPlan request/response pair. The
+// response plan starts as a copy of the proposed plan, as in the framework.
+func newModifyPlanRequest(s schema.Schema, config, plan, state tftypes.Value) (resource.ModifyPlanRequest, *resource.ModifyPlanResponse) {
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: config},
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+		State:  tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: s, Raw: plan.Copy()},
+	}
+	return req, resp
+}
+
+func TestApplyDefaultDescription(t *testing.T) {
+	ctx := context.Background()
+	r := &ResourceSecret{client: &client.Client{DefaultDescription: "Managed by Terraform"}}
+	s := resourceSchema(t, r)
+
+	cases := []struct {
+		name       string
+		configured tftypes.Value
+		client     *client.Client
+		update     bool
+		stored     string
+		wantNull   bool
+		want       string
+	}{
+		{
+			name:       "omitted description takes the default",
+			configured: tftypes.NewValue(tftypes.String, nil),
+			client:     r.client,
+			want:       "Managed by Terraform",
+		},
+		{
+			name:       "explicit description overrides the default",
+			configured: tftypes.NewValue(tftypes.String, "payments token"),
+			client:     r.client,
+			want:       "payments token",
+		},
+		{
+			name:       "no default leaves the description null",
+			configured: tftypes.NewValue(tftypes.String, nil),
+			client:     &client.Client{},
+			wantNull:   true,
+		},
+		{
+			name:       "removing a description clears it",
+			configured: tftypes.NewValue(tftypes.String, nil),
+			client:     &client.Client{},
+			update:     true,
+			stored:     "payments token",
+			wantNull:   true,
+		},
+		{
+			name:       "removing a description falls back to the default",
+			configured: tftypes.NewValue(tftypes.String, nil),
+			client:     r.client,
+			update:     true,
+			stored:     "payments token",
+			want:       "Managed by Terraform",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "token"),
+				"description": tc.configured,
+			})
+			// Terraform proposes unknown for a computed attribute left null
+			// in config.
+			plannedDescription := tc.configured
+			if tc.configured.IsNull() {
+				plannedDescription = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			}
+			plan := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"name":        tftypes.NewValue(tftypes.String, "token"),
+				"description": plannedDescription,
+				"value_hash":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			})
+
+			state := nullSchemaObjectValue(s)
+			if tc.update {
+				state = schemaObjectValue(t, s, map[string]tftypes.Value{
+					"id":          tftypes.NewValue(tftypes.String, "secret-1"),
+					"name":        tftypes.NewValue(tftypes.String, "token"),
+					"description": tftypes.NewValue(tftypes.String, tc.stored),
+				})
+			}
+
+			req, resp := newModifyPlanRequest(s, config, plan, state)
+			(&ResourceSecret{client: tc.client}).ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("description"), &got)...)
+			if tc.wantNull {
+				if !got.IsNull() {
+					t.Errorf("expected null description, got %v", got)
+				}
+				return
+			}
+			if got.ValueString() != tc.want {
+				t.Errorf("expected description %q, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+func TestResourceOrganizationIDOverride(t *testing.T) {
+	ctx := context.Background()
+	operations := tftypes.Tuple{ElementTypes: []tftypes.Type{}}
+	transformConfig := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"operations": operations}}
+
+	for _, tt := range []struct {
+		name     string
+		resource func(c *client.Client) resource.Resource
+		// path is the create endpoint, formatted with the organization.
+		path   string
+		values map[string]tftypes.Value
+	}{
+		{
+			name:     "input",
+			resource: func(c *client.Client) resource.Resource { return &ResourceInput{client: c} },
+			path:     "/api/v2/%s/inputs",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "events"),
+				"type": tftypes.NewValue(tftypes.String, "demo"),
+			},
+		},
+		{
+			name:     "output",
+			resource: func(c *client.Client) resource.Resource { return &ResourceOutput{client: c} },
+			path:     "/api/v2/%s/outputs",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "warehouse"),
+				"type": tftypes.NewValue(tftypes.String, "s3"),
+			},
+		},
+		{
+			name:     "enrichment",
+			resource: func(c *client.Client) resource.Resource { return &ResourceEnrichment{client: c} },
+			path:     "/api/v3/%s/enrichments",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "geoip"),
+				"type": tftypes.NewValue(tftypes.String, "geoip"),
+			},
+		},
+		{
+			name:     "secret",
+			resource: func(c *client.Client) resource.Resource { return &ResourceSecret{client: c} },
+			path:     "/api/v2/%s/secrets",
+			values: map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "token"),
+				"value": tftypes.NewValue(tftypes.String, "example"),
+			},
+		},
+		{
+			name:     "transform",
+			resource: func(c *client.Client) resource.Resource { return &ResourceTransform{client: c} },
+			path:     "/api/v1/%s/transforms",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "passthrough"),
+				"config": tftypes.NewValue(transformConfig, map[string]tftypes.Value{
+					"operations": tftypes.NewValue(operations, []tftypes.Value{}),
+				}),
+			},
+		},
+	} {
+		for _, override := range []string{"", "org-2"} {
+			wantOrg := override
+			if wantOrg == "" {
+				wantOrg = "org"
+			}
+
+			t.Run(tt.name+"/"+wantOrg, func(t *testing.T) {
+				var gotPath string
+				r := tt.resource(newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+					gotPath = r.URL.Path
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"id": "id-1", "name": "n", "description": ""}`))
+				}))
+				s := resourceSchema(t, r)
+
+				values := map[string]tftypes.Value{}
+				for k, v := range tt.values {
+					values[k] = v
+				}
+				if override != "" {
+					values["organization_id"] = tftypes.NewValue(tftypes.String, override)
+				}
+				value := schemaObjectValue(t, s, values)
+
+				resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+				r.Create(ctx, resource.CreateRequest{
+					Config: tfsdk.Config{Schema: s, Raw: value},
+					Plan:   tfsdk.Plan{Schema: s, Raw: value},
+				}, resp)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+				}
+
+				if want := fmt.Sprintf(tt.path, wantOrg); gotPath != want {
+					t.Errorf("expected a request to %s, got %s", want, gotPath)
+				}
+				var got types.String
+				resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("organization_id"), &got)...)
+				if got.ValueString() != wantOrg {
+					t.Errorf("expected organization_id %q in state, got %s", wantOrg, got)
+				}
+			})
+		}
+	}
+}
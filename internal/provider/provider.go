@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,10 +29,20 @@ type MonadProvider struct {
 }
 
 type MonadProviderModel struct {
-	BaseURL        types.String `tfsdk:"base_url"`
-	APIToken       types.String `tfsdk:"api_token"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	UseInsecure    types.Bool   `tfsdk:"use_insecure"`
+	BaseURL             types.String `tfsdk:"base_url"`
+	APIToken            types.String `tfsdk:"api_token"`
+	OrganizationID      types.String `tfsdk:"organization_id"`
+	UseInsecure         types.Bool   `tfsdk:"use_insecure"`
+	DefaultDescription  types.String `tfsdk:"default_description"`
+	ValidateComponents  types.Bool   `tfsdk:"validate_components"`
+	ValidateUniqueNames types.Bool   `tfsdk:"validate_unique_names"`
+	MaxRetries          types.Int64  `tfsdk:"max_retries"`
+	PipelineMaxRetries  types.Int64  `tfsdk:"pipeline_max_retries"`
+	RetryWaitMin        types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax        types.String `tfsdk:"retry_wait_max"`
+	MaxErrorBodyBytes   types.Int64  `tfsdk:"max_error_body_bytes"`
+	DefaultBatchSize    types.Int64  `tfsdk:"default_batch_size"`
+	IdleConnTimeout     types.Int64  `tfsdk:"idle_conn_timeout_seconds"`
 }
 
 func (p *MonadProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,6 +70,46 @@ func (p *MonadProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Set to true to skip TLS verification. Not recommended for production use. Can also be set with the MONAD_USE_INSECURE environment variable.",
 				Optional:            true,
 			},
+			"default_description": schema.StringAttribute{
+				MarkdownDescription: "Description applied to any resource whose `description` is omitted, e.g. `Managed by Terraform`. An explicit `description` always takes precedence.",
+				Optional:            true,
+			},
+			"default_batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Batch size applied to `monad_output` resources of type `http` (`max_batch_record_count`) and `s3` (`batch_size`) whose `config.settings` omit it. An explicit setting always takes precedence.",
+				Optional:            true,
+			},
+			"validate_components": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to check at plan time that every pipeline node references an existing component of its declared `component_type`. Costs one API call per node.",
+				Optional:            true,
+			},
+			"validate_unique_names": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to fail the plan when a new `monad_input` or `monad_output` would reuse the name of an existing input or output in the organization. Lists the organization's connectors once per new connector.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times an API request that fails transiently is retried, with exponential backoff: `GET`, `PUT` and `DELETE` requests after a network error or a 429, 502, 503 or 504 response, creates only after a 429 or 503. Defaults to 3; set to 0 to disable retries.",
+				Optional:            true,
+			},
+			"pipeline_max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Overrides `max_retries` for `monad_pipeline` operations, whose create and update calls are heavier than other requests.",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry, as a duration such as `500ms` or `2s`; each further retry doubles it. Defaults to `500ms`.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Longest delay between retries, as a duration such as `10s` or `1m`. Also caps how long a `Retry-After` response header is honoured. Defaults to `10s`.",
+				Optional:            true,
+			},
+			"max_error_body_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of bytes of an API response body quoted in error messages; longer bodies are truncated. Defaults to 4096.",
+				Optional:            true,
+			},
+			"idle_conn_timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds an idle keep-alive connection to the API is kept open for reuse. Defaults to 90; set to 0 to keep idle connections open indefinitely.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -109,15 +163,83 @@ func (p *MonadProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		isInsecure = true
 	}
 
+	for _, retries := range []struct {
+		name  string
+		value types.Int64
+	}{
+		{"max_retries", data.MaxRetries},
+		{"pipeline_max_retries", data.PipelineMaxRetries},
+	} {
+		if retries.value.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(retries.name),
+				"Invalid retry count",
+				fmt.Sprintf("%s cannot be negative.", retries.name),
+			)
+		}
+	}
+
+	retryWaitMin := parseRetryWait(&resp.Diagnostics, "retry_wait_min", data.RetryWaitMin, client.DefaultRetryWaitMin)
+	retryWaitMax := parseRetryWait(&resp.Diagnostics, "retry_wait_max", data.RetryWaitMax, client.DefaultRetryWaitMax)
+	if !resp.Diagnostics.HasError() && retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid retry wait",
+			fmt.Sprintf("retry_wait_min (%s) must not be greater than retry_wait_max (%s).", retryWaitMin, retryWaitMax),
+		)
+	}
+
+	if !data.DefaultBatchSize.IsNull() && data.DefaultBatchSize.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_batch_size"),
+			"Invalid batch size",
+			"default_batch_size must be at least 1.",
+		)
+	}
+
+	if !data.MaxErrorBodyBytes.IsNull() && data.MaxErrorBodyBytes.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_error_body_bytes"),
+			"Invalid error body size",
+			"max_error_body_bytes must be at least 1.",
+		)
+	}
+
+	if !data.IdleConnTimeout.IsNull() && data.IdleConnTimeout.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("idle_conn_timeout_seconds"),
+			"Invalid idle connection timeout",
+			"idle_conn_timeout_seconds must not be negative.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	client := client.NewMonadAPIClient(baseURL, apiToken, organizationID, isInsecure)
+	client.DefaultDescription = data.DefaultDescription.ValueString()
+	client.ValidateComponents = data.ValidateComponents.ValueBool()
+	client.ValidateUniqueNames = data.ValidateUniqueNames.ValueBool()
+	client.DefaultBatchSize = data.DefaultBatchSize.ValueInt64()
+	client.MaxErrorBodyBytes = int(data.MaxErrorBodyBytes.ValueInt64())
+	if !data.IdleConnTimeout.IsNull() {
+		client.SetIdleConnTimeout(time.Duration(data.IdleConnTimeout.ValueInt64()) * time.Second)
+	}
+	if !data.MaxRetries.IsNull() {
+		client.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.PipelineMaxRetries.IsNull() {
+		pipelineMaxRetries := int(data.PipelineMaxRetries.ValueInt64())
+		client.PipelineMaxRetries = &pipelineMaxRetries
+	}
+	client.RetryWaitMin = retryWaitMin
+	client.RetryWaitMax = retryWaitMax
 	p.organizationID = organizationID
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *MonadProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -132,15 +254,28 @@ func (p *MonadProvider) Resources(ctx context.Context) []func() resource.Resourc
 }
 
 func (p *MonadProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewDataSourceInput,
+		NewDataSourceOutput,
+		NewDataSourcePipeline,
+		NewDataSourceSecret,
+		NewDataSourceTransform,
+	}
 }
 
 func (p *MonadProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewEphemeralSecret,
+	}
 }
 
 func (p *MonadProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewFunctionParseID,
+		NewFunctionPipelineNodes,
+		NewFunctionPipelineToHCL,
+		NewFunctionSecretRef,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -150,3 +285,21 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// parseRetryWait returns the positive duration configured in the provider
+// attribute name, or def when it is null.
+func parseRetryWait(diags *diag.Diagnostics, name string, value types.String, def time.Duration) time.Duration {
+	if value.IsNull() {
+		return def
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid retry wait",
+			fmt.Sprintf("%s must be a positive duration such as \"500ms\" or \"10s\", got %q.", name, value.ValueString()),
+		)
+		return def
+	}
+	return d
+}
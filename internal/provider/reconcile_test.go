@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -79,6 +80,44 @@ func TestComputeSecretsHash(t *testing.T) {
 	}
 }
 
+func TestShouldSendSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	secrets := map[string]any{"token": "abc"}
+	hash, err := computeSecretsHash(ctx, "org", secrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := &ResourceConnectorConfig{SecretsHash: types.StringValue(hash)}
+
+	cases := []struct {
+		name       string
+		prior      *ResourceConnectorConfig
+		secrets    map[string]any
+		alwaysSend types.Bool
+		want       bool
+	}{
+		{name: "unchanged, default", prior: stored, secrets: secrets, alwaysSend: types.BoolNull(), want: true},
+		{name: "unchanged, always send", prior: stored, secrets: secrets, alwaysSend: types.BoolValue(true), want: true},
+		{name: "unchanged", prior: stored, secrets: secrets, alwaysSend: types.BoolValue(false), want: false},
+		{name: "rotated", prior: stored, secrets: map[string]any{"token": "def"}, alwaysSend: types.BoolValue(false), want: true},
+		{name: "removed", prior: stored, secrets: nil, alwaysSend: types.BoolValue(false), want: true},
+		{name: "no prior hash", prior: &ResourceConnectorConfig{SecretsHash: types.StringNull()}, secrets: secrets, alwaysSend: types.BoolValue(false), want: true},
+		{name: "no prior config", prior: nil, secrets: secrets, alwaysSend: types.BoolValue(false), want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := shouldSendSecrets(ctx, "org", tc.prior, tc.secrets, tc.alwaysSend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("expected send=%v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestDynamicsSemanticallyEqual(t *testing.T) {
 	// int64 vs float64 for the same number, and nested slices, compare equal.
 	if !dynamicsSemanticallyEqual(
@@ -188,6 +227,39 @@ func TestReconcilePipelineNodesMasksOmittedSlug(t *testing.T) {
 	}
 }
 
+func TestPipelineNodeEnabled(t *testing.T) {
+	node := func(enabled types.Bool) ResourcePipelineNode {
+		return ResourcePipelineNode{
+			ComponentType: types.StringValue("input"),
+			ComponentID:   types.StringValue("c1"),
+			Slug:          types.StringValue("src"),
+			Enabled:       enabled,
+		}
+	}
+
+	request := buildPipelineRequestNodes([]ResourcePipelineNode{node(types.BoolNull()), node(types.BoolValue(false))})
+	if !request[0].Enabled || request[1].Enabled {
+		t.Errorf("expected an omitted enabled to be sent as true and false as false, got %t and %t",
+			request[0].Enabled, request[1].Enabled)
+	}
+
+	// An omitted enabled reads back as null while the API reports the node
+	// enabled, and as drift once the node is disabled outside Terraform.
+	prior := []ResourcePipelineNode{node(types.BoolNull())}
+	if got := reconcilePipelineNodes(prior, []ResourcePipelineNode{node(types.BoolValue(true))}); !got[0].Enabled.IsNull() {
+		t.Errorf("expected omitted enabled preserved as null, got %v", got[0].Enabled)
+	}
+	if got := reconcilePipelineNodes(prior, []ResourcePipelineNode{node(types.BoolValue(false))}); !got[0].Enabled.Equal(types.BoolValue(false)) {
+		t.Errorf("expected a disabled node to be adopted, got %v", got[0].Enabled)
+	}
+
+	// An explicit value round-trips.
+	prior = []ResourcePipelineNode{node(types.BoolValue(false))}
+	if got := reconcilePipelineNodes(prior, []ResourcePipelineNode{node(types.BoolValue(false))}); !got[0].Enabled.Equal(types.BoolValue(false)) {
+		t.Errorf("expected enabled = false preserved, got %v", got[0].Enabled)
+	}
+}
+
 func TestReconcilePipelineNodesImportPopulates(t *testing.T) {
 	// On import prior state is empty; the API view populates.
 	api := []ResourcePipelineNode{{
@@ -207,14 +279,14 @@ func TestReconcilePipelineEdgesMasksOmittedNameDescription(t *testing.T) {
 		Description:          types.StringNull(),
 		FromNodeInstanceSlug: types.StringValue("a"),
 		ToNodeInstanceSlug:   types.StringValue("b"),
-		Condition:            ResourcePipelineCondition{Operator: types.StringValue("and")},
+		Condition:            &ResourcePipelineCondition{Operator: types.StringValue("and")},
 	}}
 	api := []ResourcePipelineEdge{{
 		Name:                 types.StringValue("edge-1"),
 		Description:          types.StringValue("server desc"),
 		FromNodeInstanceSlug: types.StringValue("a"),
 		ToNodeInstanceSlug:   types.StringValue("b"),
-		Condition:            ResourcePipelineCondition{Operator: types.StringValue("and")},
+		Condition:            &ResourcePipelineCondition{Operator: types.StringValue("and")},
 	}}
 
 	got := reconcilePipelineEdges(prior, api)
@@ -228,7 +300,7 @@ func TestReconcilePipelineEdgesMasksOmittedNameDescription(t *testing.T) {
 		Description:          types.StringNull(),
 		FromNodeInstanceSlug: types.StringValue("a"),
 		ToNodeInstanceSlug:   types.StringValue("c"),
-		Condition:            ResourcePipelineCondition{Operator: types.StringValue("and")},
+		Condition:            &ResourcePipelineCondition{Operator: types.StringValue("and")},
 	}}
 	got = reconcilePipelineEdges(prior, drift)
 	if got[0].ToNodeInstanceSlug.ValueString() != "c" {
@@ -236,6 +308,117 @@ func TestReconcilePipelineEdgesMasksOmittedNameDescription(t *testing.T) {
 	}
 }
 
+func TestReconcilePipelineEdgesMasksOmittedCaseSensitive(t *testing.T) {
+	edge := func(caseSensitive types.Bool) []ResourcePipelineEdge {
+		return []ResourcePipelineEdge{{
+			FromNodeInstanceSlug: types.StringValue("a"),
+			ToNodeInstanceSlug:   types.StringValue("b"),
+			Condition: &ResourcePipelineCondition{
+				Operator: types.StringValue("and"),
+				Conditions: []ResourcePipelineConditionCondition{{
+					TypeID: types.StringValue("key_has_value"),
+					Config: ResourcePipelineConditionConditionConfig{CaseSensitive: caseSensitive},
+				}},
+			},
+		}}
+	}
+
+	api := edge(types.BoolValue(true))
+	got := reconcilePipelineEdges(edge(types.BoolNull()), api)
+	if cs := got[0].Condition.Conditions[0].Config.CaseSensitive; !cs.IsNull() {
+		t.Errorf("expected omitted case_sensitive preserved as null, got %v", cs)
+	}
+	if cs := api[0].Condition.Conditions[0].Config.CaseSensitive; !cs.Equal(types.BoolValue(true)) {
+		t.Errorf("expected the API edges to be left untouched, got %v", cs)
+	}
+
+	// A configured value the server disagrees with IS drift.
+	got = reconcilePipelineEdges(edge(types.BoolValue(false)), api)
+	if cs := got[0].Condition.Conditions[0].Config.CaseSensitive; !cs.Equal(types.BoolValue(true)) {
+		t.Errorf("expected case_sensitive drift to be adopted, got %v", cs)
+	}
+}
+
+func TestSortEdgesByConfigOrderParallelEdges(t *testing.T) {
+	// Two edges share the a->b node pair. Whatever order the API returns them
+	// in, and whatever ids the prior edges carry, they are ordered by their
+	// server id so every read gives the same order.
+	api := []ResourcePipelineEdge{
+		{
+			ID:                   types.StringValue("edge-2"),
+			Name:                 types.StringValue("second"),
+			FromNodeInstanceSlug: types.StringValue("a"),
+			ToNodeInstanceSlug:   types.StringValue("b"),
+		},
+		{
+			ID:                   types.StringValue("edge-1"),
+			Name:                 types.StringValue("first"),
+			FromNodeInstanceSlug: types.StringValue("a"),
+			ToNodeInstanceSlug:   types.StringValue("b"),
+		},
+	}
+
+	for _, prior := range [][]ResourcePipelineEdge{
+		{api[0], api[1]},
+		{
+			{FromNodeInstanceSlug: types.StringValue("a"), ToNodeInstanceSlug: types.StringValue("b"), ID: types.StringUnknown()},
+			{FromNodeInstanceSlug: types.StringValue("a"), ToNodeInstanceSlug: types.StringValue("b"), ID: types.StringUnknown()},
+		},
+	} {
+		for _, apiOrder := range [][]int{{0, 1}, {1, 0}} {
+			got := []ResourcePipelineEdge{api[apiOrder[0]], api[apiOrder[1]]}
+			sortEdgesByConfigOrder(got, prior)
+			if got[0].ID.ValueString() != "edge-1" || got[1].ID.ValueString() != "edge-2" {
+				t.Errorf("api order %v: expected [edge-1 edge-2], got [%s %s]",
+					apiOrder, got[0].ID.ValueString(), got[1].ID.ValueString())
+			}
+		}
+	}
+}
+
+func TestSortNodesByConfigOrderSharedComponent(t *testing.T) {
+	// The same transform is used by two nodes, and two nodes have no slug.
+	// Keyed on component_id alone these collided, so the API order leaked
+	// into state and changed between reads.
+	node := func(componentID, slug string) ResourcePipelineNode {
+		n := ResourcePipelineNode{ComponentType: types.StringValue("transform"), ComponentID: types.StringValue(componentID), Slug: types.StringNull()}
+		if slug != "" {
+			n.Slug = types.StringValue(slug)
+		}
+		return n
+	}
+	prior := []ResourcePipelineNode{
+		node("tr-1", "redact-b"),
+		node("tr-2", ""),
+		node("tr-1", "redact-a"),
+		node("tr-3", ""),
+	}
+	// The server fills in the missing slugs.
+	api := []ResourcePipelineNode{
+		node("tr-3", "gen-3"),
+		node("tr-1", "redact-a"),
+		node("tr-2", "gen-2"),
+		node("tr-1", "redact-b"),
+	}
+
+	for _, apiOrder := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {1, 3, 0, 2}} {
+		nodes := make([]ResourcePipelineNode, len(api))
+		for i, o := range apiOrder {
+			nodes[i] = api[o]
+		}
+		sortNodesByConfigOrder(nodes, prior)
+
+		got := make([]string, len(nodes))
+		for i, n := range nodes {
+			got[i] = n.Slug.ValueString()
+		}
+		want := []string{"redact-b", "gen-2", "redact-a", "gen-3"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("api order %v: expected %v, got %v", apiOrder, want, got)
+		}
+	}
+}
+
 func TestReconcilePipelineEnabled(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -299,3 +482,39 @@ func TestReconcilePipelineEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshConnectorSettingsIgnoreDrift(t *testing.T) {
+	prior, err := AnyToDynamic(map[string]any{"endpoint": "https://example.com/ingest/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The API normalizes the endpoint by dropping the trailing slash.
+	api := map[string]any{"endpoint": "https://example.com/ingest"}
+
+	for _, tt := range []struct {
+		name      string
+		ignore    types.Bool
+		wantPrior bool
+	}{
+		{name: "unset", ignore: types.BoolNull(), wantPrior: false},
+		{name: "false", ignore: types.BoolValue(false), wantPrior: false},
+		{name: "true", ignore: types.BoolValue(true), wantPrior: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ResourceConnectorModel{
+				IgnoreDrift: tt.ignore,
+				Config: &ResourceConnectorConfig{
+					Settings:    prior,
+					Secrets:     types.DynamicNull(),
+					SecretsHash: types.StringNull(),
+				},
+			}
+			if err := refreshConnectorSettings(&data, api); err != nil {
+				t.Fatal(err)
+			}
+			if got := data.Config.Settings.Equal(prior); got != tt.wantPrior {
+				t.Errorf("settings kept from state = %t, want %t (got %s)", got, tt.wantPrior, data.Config.Settings)
+			}
+		})
+	}
+}
@@ -2,22 +2,39 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
 )
 
 type ResourceConnectorModel struct {
-	ID            types.String             `tfsdk:"id"`
-	Name          types.String             `tfsdk:"name"`
-	Description   types.String             `tfsdk:"description"`
-	ComponentType types.String             `tfsdk:"type"`
-	Config        *ResourceConnectorConfig `tfsdk:"config"`
+	ID             types.String             `tfsdk:"id"`
+	Name           types.String             `tfsdk:"name"`
+	Description    types.String             `tfsdk:"description"`
+	ComponentType  types.String             `tfsdk:"type"`
+	IgnoreDrift    types.Bool               `tfsdk:"ignore_server_config_drift"`
+	AlwaysSend     types.Bool               `tfsdk:"always_send_secret"`
+	OrganizationID types.String             `tfsdk:"organization_id"`
+	ResolvedType   types.String             `tfsdk:"resolved_type"`
+	AdoptExisting  types.Bool               `tfsdk:"adopt_existing"`
+	Fingerprint    types.String             `tfsdk:"config_fingerprint"`
+	Config         *ResourceConnectorConfig `tfsdk:"config"`
+	Timeouts       *ResourceTimeouts        `tfsdk:"timeouts"`
 }
 
 type ResourceConnectorConfig struct {
@@ -26,6 +43,17 @@ type ResourceConnectorConfig struct {
 	SecretsHash types.String  `tfsdk:"secrets_hash"`
 }
 
+// requireKnownConnector checks the connector attributes sent to the API with
+// requireKnown.
+func requireKnownConnector(ctx context.Context, diags *diag.Diagnostics, m *ResourceConnectorModel) {
+	requireKnown(ctx, diags, path.Root("name"), m.Name)
+	requireKnown(ctx, diags, path.Root("type"), m.ComponentType)
+	if m.Config != nil {
+		requireKnown(ctx, diags, path.Root("config").AtName("settings"), m.Config.Settings)
+		requireKnown(ctx, diags, path.Root("config").AtName("secrets"), m.Config.Secrets)
+	}
+}
+
 func (m *ResourceConnectorModel) getSettingsAndSecrets() (map[string]any, map[string]any, error) {
 	settings := make(map[string]any)
 	secrets := make(map[string]any)
@@ -34,17 +62,22 @@ func (m *ResourceConnectorModel) getSettingsAndSecrets() (map[string]any, map[st
 		return settings, secrets, nil
 	}
 
+	// Conversion errors name the failing value, e.g.
+	// `config.settings.pagination.fields[2]: cannot convert unknown value to any`.
 	var err error
 	if !m.Config.Settings.IsNull() {
 		settings, err = tfDynamicToMapAny(m.Config.Settings)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, atPathStep("config", atPathStep("settings", err))
+		}
+		if m.ComponentType.ValueString() == httpOutputType {
+			settings = expandHTTPHeadersMap(settings)
 		}
 	}
 	if !m.Config.Secrets.IsNull() {
 		secrets, err = tfDynamicToMapAny(m.Config.Secrets)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, atPathStep("config", atPathStep("secrets", err))
 		}
 	}
 
@@ -70,14 +103,43 @@ func getConnectorSchema() schema.Schema {
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the connector",
 				Optional:            true,
+				Computed:            true,
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Type of the connector component",
 				Required:            true,
 			},
+			"resolved_type": schema.StringAttribute{
+				MarkdownDescription: "The canonical connector type reported by the API, which may differ " +
+					"from the configured `type` when the server normalizes it.",
+				Computed: true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, creating this resource takes over an existing connector " +
+					"with the same `name` and `type`, updating it to match the configuration, " +
+					"instead of creating a duplicate. Only consulted on create.",
+				Optional: true,
+			},
+			"always_send_secret": schema.BoolAttribute{
+				MarkdownDescription: "Whether every update re-sends `config.secrets`. Defaults " +
+					"to `true`. Set to `false` to send secrets only when they change (as " +
+					"tracked by `secrets_hash`); this relies on the API keeping the stored " +
+					"secrets when an update omits them.",
+				Optional: true,
+			},
+			"ignore_server_config_drift": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, Read keeps `config.settings` from state " +
+					"instead of refreshing it from the API. Use this for connectors whose " +
+					"settings the API normalizes into a persistent diff; changes made " +
+					"outside Terraform are no longer detected.",
+				Optional: true,
+			},
+			"config_fingerprint": configFingerprintAttribute(),
+			"organization_id":    organizationIDAttribute("connector"),
 		},
 
 		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 			"config": schema.SingleNestedBlock{
 				MarkdownDescription: "Connector configuration",
 				Attributes: map[string]schema.Attribute{
@@ -128,17 +190,151 @@ func finalizeConnectorSecrets(ctx context.Context, orgID string, data *ResourceC
 	return nil
 }
 
+// configFingerprintAttribute is the computed `config_fingerprint` shared by
+// the connector resources.
+func configFingerprintAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "SHA-256 fingerprint of `config.settings` and the names of the " +
+			"keys in `config.secrets`. Secret values are not part of it. Equivalent " +
+			"configurations have equal fingerprints, so it can be compared in CI to " +
+			"detect configuration drift.",
+		Computed: true,
+	}
+}
+
+// refreshConfigFingerprint sets `config_fingerprint` from the settings in
+// state and the keys of secrets. A nil secrets map means the keys are not
+// known, as when the API omits secrets on Read, and leaves the prior
+// fingerprint in place.
+func refreshConfigFingerprint(data *ResourceConnectorModel, secrets map[string]any) error {
+	if secrets == nil {
+		return nil
+	}
+
+	var settings map[string]any
+	if data.Config != nil {
+		var err error
+		settings, err = tfDynamicToMapAny(data.Config.Settings)
+		if err != nil {
+			return atPathStep("config", atPathStep("settings", err))
+		}
+		if data.ComponentType.ValueString() == httpOutputType {
+			settings = expandHTTPHeadersMap(settings)
+		}
+	}
+
+	fingerprint, err := connectorConfigFingerprint(settings, secrets)
+	if err != nil {
+		return err
+	}
+	data.Fingerprint = types.StringValue(fingerprint)
+	return nil
+}
+
+// connectorConfigFingerprint returns the hex SHA-256 of the canonical JSON
+// encoding of settings and the sorted keys of secrets. Null settings are
+// dropped and numbers are compared by value, so settings that differ only in
+// their Terraform type (object or map, tuple or list) or in omitted nulls
+// share a fingerprint. json.Marshal sorts map keys.
+func connectorConfigFingerprint(settings, secrets map[string]any) (string, error) {
+	if settings == nil {
+		settings = map[string]any{}
+	}
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode settings for fingerprinting: %w", err)
+	}
+	var canonical any
+	if err := json.Unmarshal(encoded, &canonical); err != nil {
+		return "", fmt.Errorf("failed to decode settings for fingerprinting: %w", err)
+	}
+
+	secretKeys := slices.Sorted(maps.Keys(secrets))
+	if secretKeys == nil {
+		secretKeys = []string{}
+	}
+
+	encoded, err = json.Marshal(map[string]any{
+		"settings":    withoutNulls(canonical),
+		"secret_keys": secretKeys,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode settings for fingerprinting: %w", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// withoutNulls removes null object members from a decoded JSON value. Nulls
+// inside arrays are kept, since they hold a position.
+func withoutNulls(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if item != nil {
+				out[key] = withoutNulls(item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = withoutNulls(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// shouldSendSecrets reports whether Update must send the configured secrets.
+// Unless always_send_secret is false they are always sent, since it is not
+// confirmed that the API keeps stored secrets an update omits. When it is
+// false, secret values are never read back, so the prior `secrets_hash` is the
+// only record of what the API holds: matching secrets are omitted, so an
+// unrelated change does not re-submit (and, where the server versions
+// secrets, rotate) them. An unknown prior hash, e.g. after import, always
+// sends.
+func shouldSendSecrets(ctx context.Context, orgID string, prior *ResourceConnectorConfig, secrets map[string]any, alwaysSend types.Bool) (bool, error) {
+	if alwaysSend.IsNull() || alwaysSend.IsUnknown() || alwaysSend.ValueBool() ||
+		prior == nil || prior.SecretsHash.IsNull() || prior.SecretsHash.IsUnknown() {
+		return true, nil
+	}
+
+	hash, err := computeSecretsHash(ctx, orgID, secrets)
+	if err != nil {
+		return false, err
+	}
+	return hash != prior.SecretsHash.ValueString(), nil
+}
+
 // refreshConnectorSettings updates the config block in state during Read.
 // `settings` is reconciled against prior state so genuine drift surfaces while
 // the practitioner-authored cty representation is preserved when nothing
 // changed. The write-only `secrets` stays null and `secrets_hash` is left as it
 // was in prior state (both null on import, where the config block is absent).
+// With `ignore_server_config_drift` set, prior settings are kept as-is.
 func refreshConnectorSettings(data *ResourceConnectorModel, apiSettings map[string]any) error {
 	prior := types.DynamicNull()
 	if data.Config != nil {
 		prior = data.Config.Settings
 	}
 
+	if data.IgnoreDrift.ValueBool() && !prior.IsNull() {
+		data.Config.Secrets = types.DynamicNull()
+		return nil
+	}
+
+	// Settings written with the `headers_map` shorthand are compared in the
+	// shape they were sent in.
+	if data.ComponentType.ValueString() == httpOutputType {
+		if priorMap, err := tfDynamicToMapAny(prior); err == nil && priorMap["headers_map"] != nil &&
+			dynamicsSemanticallyEqual(expandHTTPHeadersMap(priorMap), apiSettings) {
+			apiSettings = priorMap
+		}
+	}
+
 	reconciled, err := reconcileDynamic(prior, apiSettings)
 	if err != nil {
 		return err
@@ -161,6 +357,55 @@ func refreshConnectorSettings(data *ResourceConnectorModel, apiSettings map[stri
 	return nil
 }
 
+// refreshResolvedType stores the connector type reported by the API in
+// `resolved_type`. `type` keeps the configured spelling so a server-side
+// canonicalization is not reported as drift; only an import, which has no
+// prior `type`, takes it from the API.
+func refreshResolvedType(data *ResourceConnectorModel, apiType *string) {
+	if apiType == nil {
+		data.ResolvedType = data.ComponentType
+		return
+	}
+	data.ResolvedType = types.StringValue(*apiType)
+	if data.ComponentType.IsNull() {
+		data.ComponentType = data.ResolvedType
+	}
+}
+
+// requireConnectorType reports an error when Read is left without a `type`,
+// which happens only on import when the API omits it. Settings are
+// interpreted per type, so they are not refreshed without one.
+func requireConnectorType(diags *diag.Diagnostics, kind string, data *ResourceConnectorModel) {
+	if !data.ComponentType.IsNull() {
+		return
+	}
+	diags.AddError(
+		fmt.Sprintf("Unable to determine %s type", kind),
+		fmt.Sprintf(
+			"The API did not report the type of %s %q, which is needed to read its settings.",
+			kind, data.ID.ValueString(),
+		),
+	)
+}
+
+// modifyConnectorPlanForResolvedType keeps the stored `resolved_type` in the
+// plan while `type` is unchanged, leaving it unknown (to be read from the API
+// response) only on create or when `type` changes.
+func modifyConnectorPlanForResolvedType(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planType, stateType, stateResolved types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &planType)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &stateType)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("resolved_type"), &stateResolved)...)
+	if resp.Diagnostics.HasError() || !planType.Equal(stateType) || stateResolved.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_type"), stateResolved)...)
+}
+
 // modifyConnectorPlanForSecrets surfaces a plan diff when the write-only
 // `secrets` change. Write-only values are null in state and cannot produce a
 // diff on their own, so without this the provider would never notice a rotated
@@ -232,3 +477,274 @@ func modifyConnectorPlanForSecrets(ctx context.Context, orgID string, req resour
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, types.StringUnknown())...)
 	}
 }
+
+// validateConnectorType rejects a planned `type` that the connector catalog
+// does not list, so a typo fails at plan time with the valid choices instead
+// of with an opaque API error on apply. listTypes is a cached catalog lookup
+// on the client, so planning many connectors costs one catalog request. If
+// the catalog cannot be fetched the check is skipped with a warning; the API
+// still validates the type on apply.
+//
+// Only creates are rejected. An existing connector keeps planning when its
+// type drops out of the catalog (deprecated or renamed server-side), and a
+// changed type on one is reported as a warning, since the catalog is not the
+// authority on what the API will still accept for it.
+func validateConnectorType(
+	ctx context.Context,
+	c *client.Client,
+	kind string,
+	listTypes func(context.Context) ([]string, *http.Response, error),
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &planned)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		var current types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &current)...)
+		if resp.Diagnostics.HasError() || current.Equal(planned) {
+			return
+		}
+	}
+
+	typeIDs, monadResp, err := listTypes(ctx)
+	if err != nil {
+		warnCatalogUnavailable(c, &resp.Diagnostics, kind, err, monadResp)
+		return
+	}
+	if len(typeIDs) == 0 {
+		return
+	}
+	if slices.Contains(typeIDs, planned.ValueString()) {
+		return
+	}
+
+	sorted := slices.Clone(typeIDs)
+	slices.Sort(sorted)
+	summary := fmt.Sprintf("Unknown %s type", kind)
+	detail := fmt.Sprintf(
+		"%q is not a known %s type. Valid types are: %s.",
+		planned.ValueString(), kind, strings.Join(sorted, ", "),
+	)
+	if creating {
+		resp.Diagnostics.AddAttributeError(path.Root("type"), summary, detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("type"), summary, detail)
+}
+
+// warnConnectorRename warns when an existing connector's `name` changes.
+// monad_pipeline nodes reference connectors by `component_id`, which a rename
+// keeps, but anything that looks connectors up by name (scripts, dashboards,
+// other configurations) will no longer find this one.
+func warnConnectorRename(ctx context.Context, kind string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var prior, planned types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planned)...)
+	if resp.Diagnostics.HasError() || prior.IsNull() || planned.IsUnknown() || prior.Equal(planned) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("name"),
+		fmt.Sprintf("Renaming %s", kind),
+		fmt.Sprintf(
+			"The %s is being renamed from %q to %q. Pipelines that reference it by id are "+
+				"unaffected, but anything that refers to it by name must be updated.",
+			kind, prior.ValueString(), planned.ValueString(),
+		),
+	)
+}
+
+// warnCatalogUnavailable warns that plan-time checks backed by the kind
+// connector catalog were skipped because it could not be fetched. The plan
+// proceeds and the API validates on apply; the warning is only added for the
+// first failure of the provider instance, not repeated for every resource.
+func warnCatalogUnavailable(c *client.Client, diags *diag.Diagnostics, kind string, err error, monadResp *http.Response) {
+	if !c.FirstCatalogFailure() {
+		return
+	}
+	diags.AddWarning(
+		"Connector catalog unavailable",
+		fmt.Sprintf(
+			"Unable to fetch the %s catalog, got error: %s. Response: %s. Plan-time checks "+
+				"that rely on the catalog are skipped; the Monad API still validates the "+
+				"configuration on apply.",
+			kind,
+			err,
+			getResponseBody(c, monadResp),
+		),
+	)
+}
+
+// connectorSummary identifies an existing connector when matching by name.
+type connectorSummary struct {
+	ID   string
+	Name string
+	Type string
+}
+
+// connectorLister lists every connector of one kind in an organization.
+type connectorLister func(context.Context) ([]connectorSummary, *http.Response, error)
+
+// validateUniqueConnectorName fails the plan of a new connector whose `name`
+// is already used by another connector of the same kind. Some lookups assume
+// names are unique, and duplicates are hard to tell apart in the UI. A failure
+// to list the existing connectors is reported as a warning, since the API
+// remains the authority. Connectors set to `adopt_existing` are skipped: a
+// matching name is what they are looking for.
+func validateUniqueConnectorName(
+	ctx context.Context,
+	c *client.Client,
+	kind string,
+	list connectorLister,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Only creates introduce a new name; renames are left to the API.
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+	var adopt types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("adopt_existing"), &adopt)...)
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() || adopt.ValueBool() {
+		return
+	}
+
+	connectors, monadResp, err := list(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("name"),
+			fmt.Sprintf("Unable to check %s name uniqueness", kind),
+			fmt.Sprintf(
+				"Listing existing %ss failed, got error: %s. Response: %s",
+				kind,
+				err,
+				getResponseBody(c, monadResp),
+			),
+		)
+		return
+	}
+	if !slices.ContainsFunc(connectors, func(connector connectorSummary) bool { return connector.Name == name.ValueString() }) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("name"),
+		fmt.Sprintf("Duplicate %s name", kind),
+		fmt.Sprintf(
+			"An %s named %q already exists in this organization. Choose a different name, "+
+				"or import the existing %s. This check is enabled by the provider's "+
+				"`validate_unique_names` setting.",
+			kind, name.ValueString(), kind,
+		),
+	)
+}
+
+// findAdoptableConnector returns the id of the existing connector that the
+// create of data should take over when `adopt_existing` is set: the one with
+// the same name and type. It returns "" when adoption is off or nothing
+// matches, and adds an error when the lookup fails or the match is ambiguous.
+func findAdoptableConnector(ctx context.Context, c *client.Client, kind string, list connectorLister, data *ResourceConnectorModel, diags *diag.Diagnostics) string {
+	if !data.AdoptExisting.ValueBool() {
+		return ""
+	}
+
+	connectors, monadResp, err := list(ctx)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf(
+				"Unable to list %ss to adopt an existing one, got error: %s. Response: %s",
+				kind,
+				err,
+				getResponseBody(c, monadResp),
+			),
+		)
+		return ""
+	}
+
+	var matches []string
+	for _, connector := range connectors {
+		if connector.Name == data.Name.ValueString() && connector.Type == data.ComponentType.ValueString() {
+			matches = append(matches, connector.ID)
+		}
+	}
+	if len(matches) > 1 {
+		diags.AddAttributeError(
+			path.Root("adopt_existing"),
+			fmt.Sprintf("Ambiguous %s to adopt", kind),
+			fmt.Sprintf(
+				"%d %ss are named %q with type %q (ids %s). Import the intended one instead.",
+				len(matches), kind, data.Name.ValueString(), data.ComponentType.ValueString(),
+				strings.Join(matches, ", "),
+			),
+		)
+		return ""
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+// inputConnectors lists every input in organizationID.
+func inputConnectors(c *client.Client, organizationID string) connectorLister {
+	return func(ctx context.Context) ([]connectorSummary, *http.Response, error) {
+		inputs, resp, err := c.ListInputs(ctx, organizationID)
+		if err != nil {
+			return nil, resp, err
+		}
+		out := make([]connectorSummary, 0, len(inputs))
+		for _, input := range inputs {
+			out = append(out, connectorSummary{ID: input.GetId(), Name: input.GetName(), Type: input.GetType()})
+		}
+		return out, resp, nil
+	}
+}
+
+// outputConnectors lists every output in organizationID.
+func outputConnectors(c *client.Client, organizationID string) connectorLister {
+	return func(ctx context.Context) ([]connectorSummary, *http.Response, error) {
+		outputs, resp, err := c.ListOutputs(ctx, organizationID)
+		if err != nil {
+			return nil, resp, err
+		}
+		out := make([]connectorSummary, 0, len(outputs))
+		for _, output := range outputs {
+			out = append(out, connectorSummary{ID: output.GetId(), Name: output.GetName(), Type: output.GetType()})
+		}
+		return out, resp, nil
+	}
+}
+
+// enrichmentConnectors lists every enrichment in organizationID.
+func enrichmentConnectors(c *client.Client, organizationID string) connectorLister {
+	return func(ctx context.Context) ([]connectorSummary, *http.Response, error) {
+		enrichments, resp, err := c.ListEnrichments(ctx, organizationID)
+		if err != nil {
+			return nil, resp, err
+		}
+		out := make([]connectorSummary, 0, len(enrichments))
+		for _, enrichment := range enrichments {
+			out = append(out, connectorSummary{ID: enrichment.GetId(), Name: enrichment.GetName(), Type: enrichment.GetType()})
+		}
+		return out, resp, nil
+	}
+}
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeConnectorAPI stores inputs, outputs and enrichments in memory, keyed by
+// collection and id, and serves them the way the Monad API does.
+type fakeConnectorAPI struct {
+	mu    sync.Mutex
+	items map[string]map[string]any
+	next  int
+}
+
+var fakeConnectorPath = regexp.MustCompile(`^/api/v\d/org/(inputs|outputs|enrichments)(?:/([^/]+))?$`)
+
+func (f *fakeConnectorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	m := fakeConnectorPath.FindStringSubmatch(r.URL.Path)
+	if m == nil {
+		http.NotFound(w, r)
+		return
+	}
+	collection, id := m[1], m[2]
+	key := collection + "/" + id
+
+	var body struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		Type        string  `json:"type"`
+		OutputType  string  `json:"output_type"`
+		Config      struct {
+			Settings map[string]any `json:"settings"`
+		} `json:"config"`
+	}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		f.next++
+		id = fmt.Sprintf("%s-%d", collection, f.next)
+		key = collection + "/" + id
+		fallthrough
+	case r.Method == http.MethodPut && f.items[key] != nil:
+		connectorType := body.Type
+		if body.OutputType != "" {
+			connectorType = body.OutputType
+		}
+		f.items[key] = map[string]any{
+			"id":          id,
+			"name":        body.Name,
+			"description": body.Description,
+			"type":        connectorType,
+			"config":      map[string]any{"settings": body.Config.Settings},
+		}
+	case r.Method == http.MethodGet && f.items[key] != nil:
+	case r.Method == http.MethodDelete && f.items[key] != nil:
+		delete(f.items, key)
+		_, _ = w.Write([]byte(`{}`))
+		return
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "not found"}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(f.items[key])
+}
+
+// TestConnectorResourcesCRUDConformance runs the same lifecycle against
+// monad_input, monad_output and monad_enrichment, which share
+// ResourceConnectorModel and must behave identically.
+func TestConnectorResourcesCRUDConformance(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		kind          string
+		connectorType string
+		new           func() resource.Resource
+	}{
+		{kind: "input", connectorType: "okta", new: NewResourceInput},
+		{kind: "output", connectorType: "s3", new: NewResourceOutput},
+		{kind: "enrichment", connectorType: "ipinfo", new: NewResourceEnrichment},
+	} {
+		t.Run(tc.kind, func(t *testing.T) {
+			api := &fakeConnectorAPI{items: map[string]map[string]any{}}
+			c := newTestClient(t, api.ServeHTTP)
+			r := tc.new()
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &resource.ConfigureResponse{})
+			s := resourceSchema(t, r)
+
+			read := func(state tfsdk.State) tfsdk.State {
+				t.Helper()
+				resp := &resource.ReadResponse{State: state}
+				r.Read(ctx, resource.ReadRequest{State: state}, resp)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("read: unexpected diagnostics %s", resp.Diagnostics)
+				}
+				return resp.State
+			}
+
+			// Create, then refresh without drift.
+			planned := connectorValue(t, s, tc.connectorType, map[string]string{"region": "us"})
+			createResp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+			r.Create(ctx, resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: planned},
+				Plan:   tfsdk.Plan{Schema: s, Raw: planned},
+			}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("create: unexpected diagnostics %s", createResp.Diagnostics)
+			}
+			var data ResourceConnectorModel
+			if diags := createResp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if want := tc.kind + "s-1"; data.ID.ValueString() != want || data.ResolvedType.ValueString() != tc.connectorType {
+				t.Fatalf("create: expected id %s and resolved_type %s, got %s and %s", want, tc.connectorType, data.ID, data.ResolvedType)
+			}
+			if refreshed := read(createResp.State); !refreshed.Raw.Equal(createResp.State.Raw) {
+				t.Errorf("read after create: expected no drift, got %s", refreshed.Raw)
+			}
+			if data.Fingerprint.ValueString() == "" {
+				t.Error("create: expected config_fingerprint to be set")
+			}
+
+			// Update a setting, then refresh without drift.
+			changed := connectorValue(t, s, tc.connectorType, map[string]string{"region": "eu"})
+			var plan ResourceConnectorModel
+			if diags := (tfsdk.State{Schema: s, Raw: changed}).Get(ctx, &plan); diags.HasError() {
+				t.Fatal(diags)
+			}
+			plan.ID, plan.ResolvedType, plan.OrganizationID = data.ID, data.ResolvedType, data.OrganizationID
+			planState := tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}
+			if diags := planState.Set(ctx, &plan); diags.HasError() {
+				t.Fatal(diags)
+			}
+			updateResp := &resource.UpdateResponse{State: createResp.State}
+			r.Update(ctx, resource.UpdateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: changed},
+				Plan:   tfsdk.Plan{Schema: s, Raw: planState.Raw},
+				State:  createResp.State,
+			}, updateResp)
+			if updateResp.Diagnostics.HasError() {
+				t.Fatalf("update: unexpected diagnostics %s", updateResp.Diagnostics)
+			}
+			if refreshed := read(updateResp.State); !refreshed.Raw.Equal(updateResp.State.Raw) {
+				t.Errorf("read after update: expected no drift, got %s", refreshed.Raw)
+			}
+			var updated ResourceConnectorModel
+			if diags := updateResp.State.Get(ctx, &updated); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if updated.Fingerprint.IsNull() || updated.Fingerprint.Equal(data.Fingerprint) {
+				t.Errorf("update: expected a new config_fingerprint, got %s", updated.Fingerprint)
+			}
+
+			// Delete removes the connector from the API.
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf("delete: unexpected diagnostics %s", deleteResp.Diagnostics)
+			}
+			if len(api.items) != 0 {
+				t.Errorf("delete: expected the %s to be gone, got %v", tc.kind, api.items)
+			}
+		})
+	}
+}
+
+func TestConnectorConfigFingerprint(t *testing.T) {
+	fingerprint := func(settings attr.Value, secrets map[string]any) string {
+		t.Helper()
+		data := &ResourceConnectorModel{
+			ComponentType: types.StringValue("s3"),
+			Config:        &ResourceConnectorConfig{Settings: types.DynamicValue(settings)},
+		}
+		if err := refreshConfigFingerprint(data, secrets); err != nil {
+			t.Fatal(err)
+		}
+		return data.Fingerprint.ValueString()
+	}
+
+	// An object with a tuple and a null member, as written in HCL.
+	base := fingerprint(types.ObjectValueMust(
+		map[string]attr.Type{
+			"bucket":  types.StringType,
+			"batch":   types.NumberType,
+			"formats": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+			"prefix":  types.StringType,
+		},
+		map[string]attr.Value{
+			"bucket":  types.StringValue("logs"),
+			"batch":   types.NumberValue(big.NewFloat(100)),
+			"formats": types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("json"), types.StringValue("csv")}),
+			"prefix":  types.StringNull(),
+		},
+	), map[string]any{"access_key": "a", "secret_key": "s"})
+
+	for name, tt := range map[string]struct {
+		settings attr.Value
+		secrets  map[string]any
+		equal    bool
+	}{
+		"equivalent settings and other secret values": {
+			settings: types.ObjectValueMust(
+				map[string]attr.Type{
+					"batch":   types.Int64Type,
+					"bucket":  types.StringType,
+					"formats": types.ListType{ElemType: types.StringType},
+				},
+				map[string]attr.Value{
+					"batch":   types.Int64Value(100),
+					"bucket":  types.StringValue("logs"),
+					"formats": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("json"), types.StringValue("csv")}),
+				},
+			),
+			secrets: map[string]any{"secret_key": "rotated", "access_key": "b"},
+			equal:   true,
+		},
+		"changed setting": {
+			settings: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"bucket": types.StringValue("audit"),
+			}),
+			secrets: map[string]any{"access_key": "a", "secret_key": "s"},
+		},
+		"different secret keys": {
+			settings: types.ObjectValueMust(
+				map[string]attr.Type{
+					"bucket":  types.StringType,
+					"batch":   types.Int64Type,
+					"formats": types.ListType{ElemType: types.StringType},
+				},
+				map[string]attr.Value{
+					"bucket":  types.StringValue("logs"),
+					"batch":   types.Int64Value(100),
+					"formats": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("json"), types.StringValue("csv")}),
+				},
+			),
+			secrets: map[string]any{"access_key": "a"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := fingerprint(tt.settings, tt.secrets); (got == base) != tt.equal {
+				t.Errorf("expected equal=%t, got %s and %s", tt.equal, got, base)
+			}
+		})
+	}
+
+	// Secrets the API does not return keep the prior fingerprint.
+	data := &ResourceConnectorModel{Fingerprint: types.StringValue(base)}
+	if err := refreshConfigFingerprint(data, nil); err != nil || data.Fingerprint.ValueString() != base {
+		t.Errorf("expected the prior fingerprint to be kept, got %s (%v)", data.Fingerprint, err)
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -84,14 +85,43 @@ func (r *ResourceEnrichment) Schema(
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the enrichment",
 				Optional:            true,
+				Computed:            true,
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Type of the enrichment",
 				Required:            true,
 			},
+			"resolved_type": schema.StringAttribute{
+				MarkdownDescription: "The canonical enrichment type reported by the API, which may differ " +
+					"from the configured `type` when the server normalizes it.",
+				Computed: true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, creating this resource takes over an existing enrichment " +
+					"with the same `name` and `type`, updating it to match the configuration, " +
+					"instead of creating a duplicate. Only consulted on create.",
+				Optional: true,
+			},
+			"always_send_secret": schema.BoolAttribute{
+				MarkdownDescription: "Whether every update re-sends `config.secrets`. Defaults " +
+					"to `true`. Set to `false` to send secrets only when they change (as " +
+					"tracked by `secrets_hash`); this relies on the API keeping the stored " +
+					"secrets when an update omits them.",
+				Optional: true,
+			},
+			"ignore_server_config_drift": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, Read keeps `config.settings` from state " +
+					"instead of refreshing it from the API. Use this for connectors whose " +
+					"settings the API normalizes into a persistent diff; changes made " +
+					"outside Terraform are no longer detected.",
+				Optional: true,
+			},
+			"config_fingerprint": configFingerprintAttribute(),
+			"organization_id":    organizationIDAttribute("enrichment"),
 		},
 
 		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 			"config": schema.SingleNestedBlock{
 				MarkdownDescription: "Enrichment configuration",
 				Attributes: map[string]schema.Attribute{
@@ -127,6 +157,21 @@ func (r *ResourceEnrichment) Create(
 	var data ResourceConnectorModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	requireKnownConnector(ctx, &resp.Diagnostics, &data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -151,19 +196,22 @@ func (r *ResourceEnrichment) Create(
 		},
 	}
 
-	enrichment, monadResp, err := r.client.OrganizationEnrichmentsAPI.
-		V3OrganizationIdEnrichmentsPost(ctx, r.client.OrganizationID).
-		RoutesV3CreateEnrichmentRequest(request).
-		Execute()
+	existingID := findAdoptableConnector(ctx, r.client, "enrichment", enrichmentConnectors(r.client, organizationID), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var enrichment *monad.ModelsEnrichment
+	var monadResp *http.Response
+	if existingID != "" {
+		// Adopting replaces the existing enrichment's configuration with this one.
+		tflog.Info(ctx, "adopting an existing enrichment", map[string]any{"id": existingID})
+		enrichment, monadResp, err = r.client.UpdateEnrichment(ctx, organizationID, existingID, monad.RoutesV3PutEnrichmentRequest(request))
+	} else {
+		enrichment, monadResp, err = r.client.CreateEnrichment(ctx, organizationID, request)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create enrichment, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create enrichment", err, monadResp)
 		return
 	}
 
@@ -173,10 +221,16 @@ func (r *ResourceEnrichment) Create(
 	// produced inconsistent result after apply"). Secrets are write-only, so
 	// they are nulled in state and fingerprinted into secrets_hash.
 	data.ID = types.StringValue(*enrichment.Id)
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	data.OrganizationID = types.StringValue(organizationID)
+	data.ResolvedType = types.StringPointerValue(enrichment.Type)
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint enrichment secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint enrichment config", err.Error())
+		return
+	}
 
 	tflog.Trace(ctx, "created an enrichment resource")
 
@@ -195,38 +249,40 @@ func (r *ResourceEnrichment) Read(
 		return
 	}
 
-	enrichment, monadResp, err := r.client.OrganizationEnrichmentsAPI.
-		V3OrganizationIdEnrichmentsEnrichmentIdGet(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read enrichment, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	description := types.StringNull()
-	if enrichment.Description != nil && *enrichment.Description != "" {
-		description = types.StringValue(*enrichment.Description)
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	enrichment, monadResp, err := r.client.GetEnrichment(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(r.client, &resp.Diagnostics, "enrichment", data.ID.ValueString(), err, monadResp)
+		return
 	}
 
 	data.ID = types.StringValue(*enrichment.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 	data.Name = types.StringValue(*enrichment.Name)
-	data.Description = description
-	data.ComponentType = types.StringValue(*enrichment.Type)
-	if err := refreshConnectorSettings(&data, enrichment.Config.Settings); err != nil {
+	data.Description = refreshDescription(data.Description, enrichment.Description)
+	refreshResolvedType(&data, enrichment.Type)
+	// The API may omit `config` entirely, and the secrets it does return are
+	// redacted; only the settings are refreshed, while the write-only secrets
+	// stay null and `secrets_hash` keeps the fingerprint from the last apply.
+	apiConfig := enrichment.GetConfig()
+	tflog.Debug(ctx, "read enrichment settings", map[string]any{
+		"settings": redactSecrets(apiConfig.Settings),
+	})
+	if err := refreshConnectorSettings(&data, apiConfig.Settings); err != nil {
 		resp.Diagnostics.AddError("Failed to refresh enrichment settings", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, apiConfig.Secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint enrichment config", err.Error())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -243,6 +299,14 @@ func (r *ResourceEnrichment) Update(
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	// Write-only `secrets` are null in the plan; read them from the
 	// configuration, which is the only place their values are available.
 	var cfg ResourceConnectorModel
@@ -251,15 +315,32 @@ func (r *ResourceEnrichment) Update(
 		return
 	}
 
+	requireKnownConnector(ctx, &resp.Diagnostics, &cfg)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	settings, secrets, err := cfg.getSettingsAndSecrets()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get settings and secrets", err.Error())
 		return
 	}
 
+	var state ResourceConnectorModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sendSecrets, err := shouldSendSecrets(ctx, organizationID, state.Config, secrets, data.AlwaysSend)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint enrichment secrets", err.Error())
+		return
+	}
+
 	request := monad.RoutesV3PutEnrichmentRequest{
 		Name:        data.Name.ValueStringPointer(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		Type:        data.ComponentType.ValueStringPointer(),
 		Config: &monad.SecretProcessesorEnrichmentConfig{
 			Settings: &monad.SecretProcessesorEnrichmentConfigSettings{
@@ -270,34 +351,33 @@ func (r *ResourceEnrichment) Update(
 			},
 		},
 	}
+	if !sendSecrets {
+		// Omitted secrets keep their stored values.
+		request.Config.Secrets = nil
+	}
 
-	_, monadResp, err := r.client.OrganizationEnrichmentsAPI.
-		V3OrganizationIdEnrichmentsEnrichmentIdPut(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		RoutesV3PutEnrichmentRequest(request).
-		Execute()
+	enrichment, monadResp, err := r.client.UpdateEnrichment(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update enrichment, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update enrichment", err, monadResp)
 		return
 	}
 
 	// Preserve plan-known values (see Create); data already holds
 	// id/name/description/type/settings from the plan. Secrets stay write-only
 	// (nulled) and secrets_hash is refreshed to match what was just sent.
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint enrichment secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint enrichment config", err.Error())
+		return
+	}
+
+	data.OrganizationID = types.StringValue(organizationID)
+	if data.ResolvedType.IsUnknown() {
+		data.ResolvedType = types.StringPointerValue(enrichment.Type)
+	}
 
 	tflog.Trace(ctx, "updated an enrichment resource")
 
@@ -309,10 +389,13 @@ func (r *ResourceEnrichment) ModifyPlan(
 	req resource.ModifyPlanRequest,
 	resp *resource.ModifyPlanResponse,
 ) {
+	applyDefaultDescription(ctx, r.client, req, resp)
 	if r.client == nil {
 		return
 	}
-	modifyConnectorPlanForSecrets(ctx, r.client.OrganizationID, req, resp)
+	organizationID := plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics)
+	modifyConnectorPlanForResolvedType(ctx, req, resp)
+	modifyConnectorPlanForSecrets(ctx, organizationID, req, resp)
 }
 
 func (r *ResourceEnrichment) Delete(
@@ -327,21 +410,17 @@ func (r *ResourceEnrichment) Delete(
 		return
 	}
 
-	_, monadResp, err := r.client.OrganizationEnrichmentsAPI.
-		V3OrganizationIdEnrichmentsEnrichmentIdDelete(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	monadResp, err := r.client.DeleteEnrichment(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete enrichment, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete enrichment", err, monadResp)
 		return
 	}
 }
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourceEnrichmentSecretsSurviveRefresh(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceEnrichment{})
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"region": tftypes.String}}
+	secretsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"api_key": tftypes.String}}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/org/enrichments/en-1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Secrets come back redacted, if at all.
+		_, _ = w.Write([]byte(`{
+			"id": "en-1", "name": "geoip", "type": "ipinfo",
+			"config": {"settings": {"region": "us"}, "secrets": {"api_key": "********"}}
+		}`))
+	})
+	r := &ResourceEnrichment{client: c}
+
+	storedHash, err := computeSecretsHash(ctx, "org", map[string]any{"api_key": "k-123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := connectorConfigFingerprint(map[string]any{"region": "us"}, map[string]any{"api_key": "k-123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := tftypes.NewValue(settingsType, map[string]tftypes.Value{
+		"region": tftypes.NewValue(tftypes.String, "us"),
+	})
+	state := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, "en-1"),
+		"config_fingerprint": tftypes.NewValue(tftypes.String, fingerprint),
+		"name":               tftypes.NewValue(tftypes.String, "geoip"),
+		"type":               tftypes.NewValue(tftypes.String, "ipinfo"),
+		"resolved_type":      tftypes.NewValue(tftypes.String, "ipinfo"),
+		"organization_id":    tftypes.NewValue(tftypes.String, "org"),
+		"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+			"settings":     settings,
+			"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			"secrets_hash": tftypes.NewValue(tftypes.String, storedHash),
+		}),
+	})
+
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state.Copy()}}
+	r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read: unexpected diagnostics %s", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(state) {
+		t.Fatalf("expected the refresh to leave state unchanged, got %s", readResp.State.Raw)
+	}
+
+	// Planning the same configured secret against the refreshed state is a
+	// no-op: secrets_hash is kept rather than marked for recomputation.
+	config := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "geoip"),
+		"type": tftypes.NewValue(tftypes.String, "ipinfo"),
+		"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+			"settings": settings,
+			"secrets": tftypes.NewValue(secretsType, map[string]tftypes.Value{
+				"api_key": tftypes.NewValue(tftypes.String, "k-123"),
+			}),
+			"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+	req, resp := newModifyPlanRequest(s, config, readResp.State.Raw, readResp.State.Raw)
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("plan: unexpected diagnostics %s", resp.Diagnostics)
+	}
+	var plannedHash types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("config").AtName("secrets_hash"), &plannedHash)...)
+	if plannedHash.ValueString() != storedHash {
+		t.Errorf("expected secrets_hash to stay %s, got %s", storedHash, plannedHash)
+	}
+}
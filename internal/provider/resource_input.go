@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +19,7 @@ var _ resource.Resource = &ResourceInput{}
 var _ resource.ResourceWithConfigure = &ResourceInput{}
 var _ resource.ResourceWithImportState = &ResourceInput{}
 var _ resource.ResourceWithModifyPlan = &ResourceInput{}
+var _ resource.ResourceWithValidateConfig = &ResourceInput{}
 
 func NewResourceInput() resource.Resource {
 	return &ResourceInput{}
@@ -34,6 +37,14 @@ func (r *ResourceInput) Metadata(
 	resp.TypeName = fmt.Sprintf("%s_input", req.ProviderTypeName)
 }
 
+func (r *ResourceInput) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	resp.Diagnostics.Append(validateConnectorConfig(ctx, req.Config, inputRules)...)
+}
+
 func (r *ResourceInput) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -74,6 +85,21 @@ func (r *ResourceInput) Create(
 	var data ResourceConnectorModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	requireKnownConnector(ctx, &resp.Diagnostics, &data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -98,19 +124,22 @@ func (r *ResourceInput) Create(
 		},
 	}
 
-	input, monadResp, err := r.client.OrganizationInputsAPI.
-		V2OrganizationIdInputsPost(ctx, r.client.OrganizationID).
-		RoutesV2CreateInputRequest(request).
-		Execute()
+	existingID := findAdoptableConnector(ctx, r.client, "input", inputConnectors(r.client, organizationID), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input *monad.ModelsInput
+	var monadResp *http.Response
+	if existingID != "" {
+		// Adopting replaces the existing input's configuration with this one.
+		tflog.Info(ctx, "adopting an existing input", map[string]any{"id": existingID})
+		input, monadResp, err = r.client.UpdateInput(ctx, organizationID, existingID, monad.RoutesV2PutInputRequest(request))
+	} else {
+		input, monadResp, err = r.client.CreateInput(ctx, organizationID, request)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create input, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create input", err, monadResp)
 		return
 	}
 
@@ -120,10 +149,16 @@ func (r *ResourceInput) Create(
 	// produced inconsistent result after apply"). Secrets are write-only, so
 	// they are nulled in state and fingerprinted into secrets_hash.
 	data.ID = types.StringValue(*input.Id)
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	data.OrganizationID = types.StringValue(organizationID)
+	data.ResolvedType = types.StringPointerValue(input.Type)
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint input secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint input config", err.Error())
+		return
+	}
 
 	tflog.Trace(ctx, "created an input resource")
 
@@ -142,38 +177,43 @@ func (r *ResourceInput) Read(
 		return
 	}
 
-	input, monadResp, err := r.client.OrganizationInputsAPI.
-		V1OrganizationIdInputsInputIdGet(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read input, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	description := types.StringNull()
-	if input.Description != nil && *input.Description != "" {
-		description = types.StringValue(*input.Description)
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	input, monadResp, err := r.client.GetInput(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(r.client, &resp.Diagnostics, "input", data.ID.ValueString(), err, monadResp)
+		return
 	}
 
 	data.ID = types.StringValue(*input.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 	data.Name = types.StringValue(*input.Name)
-	data.Description = description
-	data.ComponentType = types.StringValue(*input.Type)
-	if err := refreshConnectorSettings(&data, input.Config.Settings); err != nil {
+	data.Description = refreshDescription(data.Description, input.Description)
+	// On import `type` is taken from the API here, before the settings that
+	// are interpreted by it.
+	refreshResolvedType(&data, input.Type)
+	requireConnectorType(&resp.Diagnostics, "input", &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	settings := input.GetConfig().Settings
+	tflog.Debug(ctx, "read input settings", map[string]any{
+		"settings": redactSecrets(settings),
+	})
+	if err := refreshConnectorSettings(&data, settings); err != nil {
 		resp.Diagnostics.AddError("Failed to refresh input settings", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, input.GetConfig().Secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint input config", err.Error())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -190,6 +230,14 @@ func (r *ResourceInput) Update(
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	// Write-only `secrets` are null in the plan; read them from the
 	// configuration, which is the only place their values are available.
 	var cfg ResourceConnectorModel
@@ -198,15 +246,32 @@ func (r *ResourceInput) Update(
 		return
 	}
 
+	requireKnownConnector(ctx, &resp.Diagnostics, &cfg)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	settings, secrets, err := cfg.getSettingsAndSecrets()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get settings and secrets", err.Error())
 		return
 	}
 
+	var state ResourceConnectorModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sendSecrets, err := shouldSendSecrets(ctx, organizationID, state.Config, secrets, data.AlwaysSend)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint input secrets", err.Error())
+		return
+	}
+
 	request := monad.RoutesV2PutInputRequest{
 		Name:        data.Name.ValueStringPointer(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		Type:        data.ComponentType.ValueStringPointer(),
 		Config: &monad.SecretProcessesorInputConfig{
 			Settings: &monad.SecretProcessesorInputConfigSettings{
@@ -217,34 +282,33 @@ func (r *ResourceInput) Update(
 			},
 		},
 	}
+	if !sendSecrets {
+		// Omitted secrets keep their stored values.
+		request.Config.Secrets = nil
+	}
 
-	_, monadResp, err := r.client.OrganizationInputsAPI.
-		V2OrganizationIdInputsInputIdPut(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		RoutesV2PutInputRequest(request).
-		Execute()
+	input, monadResp, err := r.client.UpdateInput(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update input, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update input", err, monadResp)
 		return
 	}
 
 	// Preserve plan-known values (see Create); data already holds
 	// id/name/description/type/settings from the plan. Secrets stay write-only
 	// (nulled) and secrets_hash is refreshed to match what was just sent.
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint input secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint input config", err.Error())
+		return
+	}
+
+	data.OrganizationID = types.StringValue(organizationID)
+	if data.ResolvedType.IsUnknown() {
+		data.ResolvedType = types.StringPointerValue(input.Type)
+	}
 
 	tflog.Trace(ctx, "updated an input resource")
 
@@ -256,10 +320,66 @@ func (r *ResourceInput) ModifyPlan(
 	req resource.ModifyPlanRequest,
 	resp *resource.ModifyPlanResponse,
 ) {
+	applyDefaultDescription(ctx, r.client, req, resp)
+	warnConnectorRename(ctx, "input", req, resp)
 	if r.client == nil {
 		return
 	}
-	modifyConnectorPlanForSecrets(ctx, r.client.OrganizationID, req, resp)
+	organizationID := plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics)
+	validateConnectorType(ctx, r.client, "input", r.client.InputTypes, req, resp)
+	if r.client.ValidateUniqueNames {
+		validateUniqueConnectorName(ctx, r.client, "input", inputConnectors(r.client, organizationID), req, resp)
+	}
+	validateDemoRecordType(ctx, r.client, req, resp)
+	modifyConnectorPlanForResolvedType(ctx, req, resp)
+	modifyConnectorPlanForSecrets(ctx, organizationID, req, resp)
+}
+
+// demoInputType is the input `type` of the demo event generator.
+const demoInputType = "demo"
+
+// validateDemoRecordType checks a demo input's `record_type` setting against
+// the record types the event generator supports. The set changes as
+// generators are added, so it is read from the (cached) input catalog; when
+// the catalog is unavailable (with a warning) or does not constrain it, the
+// API validates it.
+func validateDemoRecordType(ctx context.Context, c *client.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var inputType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &inputType)...)
+	if resp.Diagnostics.HasError() || inputType.ValueString() != demoInputType {
+		return
+	}
+
+	var settingsDyn types.Dynamic
+	if diags := req.Plan.GetAttribute(ctx, settingsPath, &settingsDyn); diags.HasError() {
+		return
+	}
+	if settingsDyn.IsUnknown() || settingsDyn.IsUnderlyingValueUnknown() {
+		return
+	}
+	settings, err := tfDynamicToMapAny(settingsDyn)
+	if err != nil {
+		return
+	}
+	if _, ok := settings["record_type"]; !ok {
+		return
+	}
+
+	recordTypes, monadResp, err := c.InputSettingValues(ctx, demoInputType, "record_type")
+	if err != nil {
+		warnCatalogUnavailable(c, &resp.Diagnostics, "input", err, monadResp)
+		return
+	}
+	if len(recordTypes) == 0 {
+		return
+	}
+	slices.Sort(recordTypes)
+
+	resp.Diagnostics.Append(validateSettingOneOf(settingsPath, settings, "record_type", recordTypes)...)
 }
 
 func (r *ResourceInput) Delete(
@@ -274,22 +394,17 @@ func (r *ResourceInput) Delete(
 		return
 	}
 
-	_, monadResp, err := r.client.OrganizationInputsAPI.
-		V1OrganizationIdInputsInputIdDelete(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	monadResp, err := r.client.DeleteInput(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete input, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete input", err, monadResp)
 		return
 	}
 }
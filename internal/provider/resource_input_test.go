@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateDemoRecordType(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/inputs" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{
+				"type_id": "demo",
+				"config": map[string]any{
+					"properties": map[string]any{
+						"settings": map[string]any{
+							"properties": map[string]any{
+								"rate":        map[string]any{"type": "integer"},
+								"record_type": map[string]any{"enum": []string{"okta_logs", "aws_cloudtrail"}},
+							},
+						},
+					},
+				},
+			},
+		})
+	})
+	r := &ResourceInput{client: c}
+
+	plan := func(recordType string) []string {
+		value := connectorValue(t, s, demoInputType, map[string]string{"record_type": recordType})
+		req, resp := newModifyPlanRequest(s, value, value, nullSchemaObjectValue(s))
+		r.ModifyPlan(ctx, req, resp)
+
+		var details []string
+		for _, d := range resp.Diagnostics.Errors() {
+			details = append(details, d.Detail())
+		}
+		return details
+	}
+
+	if errs := plan("okta_logs"); len(errs) != 0 {
+		t.Errorf("valid record type: unexpected errors %v", errs)
+	}
+
+	errs := plan("okta")
+	if len(errs) != 1 {
+		t.Fatalf("invalid record type: expected one error, got %v", errs)
+	}
+	if !strings.Contains(errs[0], "aws_cloudtrail, okta_logs") {
+		t.Errorf("expected the supported record types to be listed, got %q", errs[0])
+	}
+}
+
+// There is no typed demo input; `monad_input` with `type = "demo"` refreshes
+// record_type and rate through the generic settings reconciliation.
+func TestRefreshConnectorSettingsDemoInput(t *testing.T) {
+	prior, err := AnyToDynamic(map[string]any{"record_type": "okta_logs", "rate": int64(25)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := ResourceConnectorModel{
+		Config: &ResourceConnectorConfig{Settings: prior},
+	}
+
+	// Changed out of band; JSON numbers decode as float64.
+	var api map[string]any
+	if err := json.Unmarshal([]byte(`{"record_type": "aws_cloudtrail", "rate": 250}`), &api); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := refreshConnectorSettings(&data, api); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tfDynamicToMapAny(data.Config.Settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["record_type"] != "aws_cloudtrail" {
+		t.Errorf("expected record_type to be refreshed, got %v", got["record_type"])
+	}
+	if !dynamicsSemanticallyEqual(map[string]any{"rate": got["rate"]}, map[string]any{"rate": int64(250)}) {
+		t.Errorf("expected rate to be refreshed to 250, got %#v", got["rate"])
+	}
+}
+
+func TestValidateUniqueInputName(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/org/inputs" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"inputs":     []map[string]any{{"id": "in-1", "name": "okta"}, {"id": "in-2", "name": "github"}},
+			"pagination": map[string]any{"total": 2},
+		})
+	})
+	c.ValidateUniqueNames = true
+	r := &ResourceInput{client: c}
+
+	plan := func(name string, state tftypes.Value) *resource.ModifyPlanResponse {
+		value := schemaObjectValue(t, s, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, name),
+			"type": tftypes.NewValue(tftypes.String, "okta"),
+		})
+		req, resp := newModifyPlanRequest(s, value, value, state)
+		r.ModifyPlan(ctx, req, resp)
+		return resp
+	}
+
+	resp := plan("okta", nullSchemaObjectValue(s))
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Duplicate input name" {
+		t.Errorf("colliding name: expected a duplicate name error, got %s", resp.Diagnostics)
+	}
+
+	if resp := plan("okta-eu", nullSchemaObjectValue(s)); resp.Diagnostics.HasError() {
+		t.Errorf("unique name: unexpected diagnostics %s", resp.Diagnostics)
+	}
+
+	// An existing input keeping its own name is not a collision.
+	state := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "in-1"),
+		"name": tftypes.NewValue(tftypes.String, "okta"),
+		"type": tftypes.NewValue(tftypes.String, "okta"),
+	})
+	if resp := plan("okta", state); resp.Diagnostics.HasError() {
+		t.Errorf("update: unexpected diagnostics %s", resp.Diagnostics)
+	}
+}
+
+func TestResourceInputCreateRejectsUnknownValues(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"bucket": tftypes.String}}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call: %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	})
+	r := &ResourceInput{client: c}
+
+	value := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"type": tftypes.NewValue(tftypes.String, "s3"),
+		"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+			"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+				"bucket": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: value},
+		Plan:   tfsdk.Plan{Schema: s, Raw: value},
+	}, resp)
+
+	want := []path.Path{path.Root("name"), path.Root("config").AtName("settings")}
+	if resp.Diagnostics.ErrorsCount() != len(want) {
+		t.Fatalf("expected %d errors, got %s", len(want), resp.Diagnostics)
+	}
+	for i, d := range resp.Diagnostics.Errors() {
+		withPath, ok := d.(interface{ Path() path.Path })
+		if !ok || !withPath.Path().Equal(want[i]) {
+			t.Errorf("expected an error at %s, got %v", want[i], d)
+		}
+		if d.Summary() != "Unknown Value" || !strings.Contains(d.Detail(), "not known at apply time") {
+			t.Errorf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+		}
+	}
+}
+
+func TestResourceInputCreateAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"org_url": tftypes.String}}
+
+	var calls []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/org/inputs":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"inputs": []map[string]any{
+					{"id": "in-1", "name": "okta", "type": "okta"},
+					{"id": "in-2", "name": "okta", "type": "github"},
+				},
+				"pagination": map[string]any{"total": 2},
+			})
+		case "PUT /api/v2/org/inputs/in-1":
+			_, _ = w.Write([]byte(`{"id": "in-1", "type": "okta"}`))
+		case "POST /api/v2/org/inputs":
+			_, _ = w.Write([]byte(`{"id": "in-new", "type": "okta"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	r := &ResourceInput{client: c}
+
+	create := func(name string, adopt bool) (ResourceConnectorModel, *resource.CreateResponse) {
+		calls = nil
+		value := schemaObjectValue(t, s, map[string]tftypes.Value{
+			"name":           tftypes.NewValue(tftypes.String, name),
+			"type":           tftypes.NewValue(tftypes.String, "okta"),
+			"adopt_existing": tftypes.NewValue(tftypes.Bool, adopt),
+			"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+				"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+					"org_url": tftypes.NewValue(tftypes.String, "https://example.okta.com"),
+				}),
+				"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+				"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+			}),
+		})
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+		r.Create(ctx, resource.CreateRequest{
+			Config: tfsdk.Config{Schema: s, Raw: value},
+			Plan:   tfsdk.Plan{Schema: s, Raw: value},
+		}, resp)
+		var data ResourceConnectorModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	// The input with the same name and type is updated in place.
+	data, resp := create("okta", true)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("adopt: unexpected diagnostics %s", resp.Diagnostics)
+	}
+	if data.ID.ValueString() != "in-1" {
+		t.Errorf("adopt: expected the existing id in-1, got %s", data.ID)
+	}
+	if want := []string{"GET /api/v1/org/inputs", "PUT /api/v2/org/inputs/in-1"}; !slices.Equal(calls, want) {
+		t.Errorf("adopt: expected calls %v, got %v", want, calls)
+	}
+
+	// Nothing to adopt: a new input is created.
+	data, resp = create("okta-eu", true)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create: unexpected diagnostics %s", resp.Diagnostics)
+	}
+	if data.ID.ValueString() != "in-new" {
+		t.Errorf("create: expected the new id in-new, got %s", data.ID)
+	}
+	if want := []string{"GET /api/v1/org/inputs", "POST /api/v2/org/inputs"}; !slices.Equal(calls, want) {
+		t.Errorf("create: expected calls %v, got %v", want, calls)
+	}
+
+	// Without adopt_existing the list is never consulted.
+	if _, resp := create("okta", false); resp.Diagnostics.HasError() {
+		t.Fatalf("default: unexpected diagnostics %s", resp.Diagnostics)
+	}
+	if want := []string{"POST /api/v2/org/inputs"}; !slices.Equal(calls, want) {
+		t.Errorf("default: expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestWarnConnectorRename(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+	r := &ResourceInput{}
+
+	value := func(name string) tftypes.Value {
+		return schemaObjectValue(t, s, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "in-1"),
+			"name": tftypes.NewValue(tftypes.String, name),
+			"type": tftypes.NewValue(tftypes.String, "okta"),
+		})
+	}
+
+	req, resp := newModifyPlanRequest(s, value("okta-eu"), value("okta-eu"), value("okta"))
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("rename: expected one warning, got %s", resp.Diagnostics)
+	}
+	if w := resp.Diagnostics.Warnings()[0]; w.Summary() != "Renaming input" || !strings.Contains(w.Detail(), `"okta" to "okta-eu"`) {
+		t.Errorf("rename: unexpected warning %s: %s", w.Summary(), w.Detail())
+	}
+
+	req, resp = newModifyPlanRequest(s, value("okta"), value("okta"), value("okta"))
+	r.ModifyPlan(ctx, req, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("unchanged name: unexpected diagnostics %s", resp.Diagnostics)
+	}
+
+	req, resp = newModifyPlanRequest(s, value("okta"), value("okta"), nullSchemaObjectValue(s))
+	r.ModifyPlan(ctx, req, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("create: unexpected diagnostics %s", resp.Diagnostics)
+	}
+}
+
+// Read decodes the API's config.settings into state, so changes made outside
+// Terraform surface as drift.
+func TestResourceInputReadRefreshesSettings(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceInput{})
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+
+	for _, tc := range []struct {
+		name      string
+		inputType string
+		prior     map[string]tftypes.Value
+		body      string
+		want      map[string]any
+	}{
+		{
+			name:      "okta org_url",
+			inputType: "okta",
+			prior:     map[string]tftypes.Value{"org_url": tftypes.NewValue(tftypes.String, "https://example.okta.com")},
+			body:      `{"settings": {"org_url": "https://example-admin.okta.com"}}`,
+			want:      map[string]any{"org_url": "https://example-admin.okta.com"},
+		},
+		{
+			name:      "demo rate",
+			inputType: "demo",
+			prior: map[string]tftypes.Value{
+				"record_type": tftypes.NewValue(tftypes.String, "cloudtrail"),
+				"rate":        tftypes.NewValue(tftypes.Number, 10),
+			},
+			body: `{"settings": {"record_type": "cloudtrail", "rate": 25}}`,
+			want: map[string]any{"record_type": "cloudtrail", "rate": int64(25)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ResourceInput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"id": "in-1", "name": "audit", "type": %q, "config": %s}`, tc.inputType, tc.body)
+			})}
+
+			settingsTypes := make(map[string]tftypes.Type, len(tc.prior))
+			for k, v := range tc.prior {
+				settingsTypes[k] = v.Type()
+			}
+			state := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "in-1"),
+				"name": tftypes.NewValue(tftypes.String, "audit"),
+				"type": tftypes.NewValue(tftypes.String, tc.inputType),
+				"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+					"settings":     tftypes.NewValue(tftypes.Object{AttributeTypes: settingsTypes}, tc.prior),
+					"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+					"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+				}),
+			})
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+			r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics %s", resp.Diagnostics)
+			}
+
+			var data ResourceConnectorModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			got, err := tfDynamicToMapAny(data.Config.Settings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !dynamicsSemanticallyEqual(got, tc.want) {
+				t.Errorf("expected settings %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +20,7 @@ var _ resource.Resource = &ResourceOutput{}
 var _ resource.ResourceWithConfigure = &ResourceOutput{}
 var _ resource.ResourceWithImportState = &ResourceOutput{}
 var _ resource.ResourceWithModifyPlan = &ResourceOutput{}
+var _ resource.ResourceWithValidateConfig = &ResourceOutput{}
 
 func NewResourceOutput() resource.Resource {
 	return &ResourceOutput{}
@@ -42,6 +46,14 @@ func (r *ResourceOutput) Schema(
 	resp.Schema = getConnectorSchema()
 }
 
+func (r *ResourceOutput) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	resp.Diagnostics.Append(validateConnectorConfig(ctx, req.Config, outputRules)...)
+}
+
 func (r *ResourceOutput) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -74,6 +86,21 @@ func (r *ResourceOutput) Create(
 	var data ResourceConnectorModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	requireKnownConnector(ctx, &resp.Diagnostics, &data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -83,6 +110,7 @@ func (r *ResourceOutput) Create(
 		resp.Diagnostics.AddError("Failed to get settings and secrets", err.Error())
 		return
 	}
+	applyDefaultBatchSize(r.client, data.ComponentType.ValueString(), settings)
 
 	request := monad.RoutesV2CreateOutputRequest{
 		Name:        data.Name.ValueStringPointer(),
@@ -98,19 +126,27 @@ func (r *ResourceOutput) Create(
 		},
 	}
 
-	output, monadResp, err := r.client.OrganizationOutputsAPI.
-		V2OrganizationIdOutputsPost(ctx, r.client.OrganizationID).
-		RoutesV2CreateOutputRequest(request).
-		Execute()
+	existingID := findAdoptableConnector(ctx, r.client, "output", outputConnectors(r.client, organizationID), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var output *monad.ModelsOutput
+	var monadResp *http.Response
+	if existingID != "" {
+		// Adopting replaces the existing output's configuration with this one.
+		tflog.Info(ctx, "adopting an existing output", map[string]any{"id": existingID})
+		output, monadResp, err = r.client.UpdateOutput(ctx, organizationID, existingID, monad.RoutesV2PutOutputRequest{
+			Name:        request.Name,
+			Description: request.Description,
+			OutputType:  request.OutputType,
+			Config:      request.Config,
+		})
+	} else {
+		output, monadResp, err = r.client.CreateOutput(ctx, organizationID, request)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create output, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create output", err, monadResp)
 		return
 	}
 
@@ -120,12 +156,18 @@ func (r *ResourceOutput) Create(
 	// produced inconsistent result after apply"). Secrets are write-only, so
 	// they are nulled in state and fingerprinted into secrets_hash.
 	data.ID = types.StringValue(*output.Id)
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	data.OrganizationID = types.StringValue(organizationID)
+	data.ResolvedType = types.StringPointerValue(output.Type)
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint output secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint output config", err.Error())
+		return
+	}
 
-	tflog.Trace(ctx, "created a output resource")
+	tflog.Trace(ctx, "created an output resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -142,38 +184,44 @@ func (r *ResourceOutput) Read(
 		return
 	}
 
-	output, monadResp, err := r.client.OrganizationOutputsAPI.
-		V1OrganizationIdOutputsOutputIdGet(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read output, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	description := types.StringNull()
-	if output.Description != nil && *output.Description != "" {
-		description = types.StringValue(*output.Description)
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	output, monadResp, err := r.client.GetOutput(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(r.client, &resp.Diagnostics, "output", data.ID.ValueString(), err, monadResp)
+		return
 	}
 
 	data.ID = types.StringValue(*output.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 	data.Name = types.StringValue(*output.Name)
-	data.Description = description
-	data.ComponentType = types.StringValue(*output.Type)
-	if err := refreshConnectorSettings(&data, output.Config.Settings); err != nil {
+	data.Description = refreshDescription(data.Description, output.Description)
+	// On import `type` is taken from the API here, before the settings that
+	// are interpreted by it.
+	refreshResolvedType(&data, output.Type)
+	requireConnectorType(&resp.Diagnostics, "output", &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	settings := output.GetConfig().Settings
+	tflog.Debug(ctx, "read output settings", map[string]any{
+		"settings": redactSecrets(settings),
+	})
+	apiSettings := withoutDefaultBatchSize(r.client, &data, settings)
+	if err := refreshConnectorSettings(&data, apiSettings); err != nil {
 		resp.Diagnostics.AddError("Failed to refresh output settings", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, output.GetConfig().Secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint output config", err.Error())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -190,6 +238,14 @@ func (r *ResourceOutput) Update(
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	// Write-only `secrets` are null in the plan; read them from the
 	// configuration, which is the only place their values are available.
 	var cfg ResourceConnectorModel
@@ -198,15 +254,33 @@ func (r *ResourceOutput) Update(
 		return
 	}
 
+	requireKnownConnector(ctx, &resp.Diagnostics, &cfg)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	settings, secrets, err := cfg.getSettingsAndSecrets()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get settings and secrets", err.Error())
 		return
 	}
+	applyDefaultBatchSize(r.client, cfg.ComponentType.ValueString(), settings)
+
+	var state ResourceConnectorModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sendSecrets, err := shouldSendSecrets(ctx, organizationID, state.Config, secrets, data.AlwaysSend)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint output secrets", err.Error())
+		return
+	}
 
 	request := monad.RoutesV2PutOutputRequest{
 		Name:        data.Name.ValueStringPointer(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		OutputType:  data.ComponentType.ValueStringPointer(),
 		Config: &monad.SecretProcessesorOutputConfig{
 			Settings: &monad.SecretProcessesorOutputConfigSettings{
@@ -217,36 +291,35 @@ func (r *ResourceOutput) Update(
 			},
 		},
 	}
+	if !sendSecrets {
+		// Omitted secrets keep their stored values.
+		request.Config.Secrets = nil
+	}
 
-	_, monadResp, err := r.client.OrganizationOutputsAPI.
-		V2OrganizationIdOutputsOutputIdPut(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		RoutesV2PutOutputRequest(request).
-		Execute()
+	output, monadResp, err := r.client.UpdateOutput(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update output, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update output", err, monadResp)
 		return
 	}
 
 	// Preserve plan-known values (see Create); data already holds
 	// id/name/description/type/settings from the plan. Secrets stay write-only
 	// (nulled) and secrets_hash is refreshed to match what was just sent.
-	if err := finalizeConnectorSecrets(ctx, r.client.OrganizationID, &data, secrets); err != nil {
+	if err := finalizeConnectorSecrets(ctx, organizationID, &data, secrets); err != nil {
 		resp.Diagnostics.AddError("Failed to fingerprint output secrets", err.Error())
 		return
 	}
+	if err := refreshConfigFingerprint(&data, secrets); err != nil {
+		resp.Diagnostics.AddError("Failed to fingerprint output config", err.Error())
+		return
+	}
+
+	data.OrganizationID = types.StringValue(organizationID)
+	if data.ResolvedType.IsUnknown() {
+		data.ResolvedType = types.StringPointerValue(output.Type)
+	}
 
-	tflog.Trace(ctx, "updated a output resource")
+	tflog.Trace(ctx, "updated an output resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -256,10 +329,259 @@ func (r *ResourceOutput) ModifyPlan(
 	req resource.ModifyPlanRequest,
 	resp *resource.ModifyPlanResponse,
 ) {
+	applyDefaultDescription(ctx, r.client, req, resp)
+	modifyPostgreSQLOutputPlan(ctx, req, resp)
+	modifyHTTPOutputPlan(ctx, req, resp)
+	warnConnectorRename(ctx, "output", req, resp)
 	if r.client == nil {
 		return
 	}
-	modifyConnectorPlanForSecrets(ctx, r.client.OrganizationID, req, resp)
+	organizationID := plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics)
+	validateConnectorType(ctx, r.client, "output", r.client.OutputTypes, req, resp)
+	if r.client.ValidateUniqueNames {
+		validateUniqueConnectorName(ctx, r.client, "output", outputConnectors(r.client, organizationID), req, resp)
+	}
+	modifyConnectorPlanForResolvedType(ctx, req, resp)
+	modifyConnectorPlanForSecrets(ctx, organizationID, req, resp)
+}
+
+// batchSizeSettings names the batch size setting of each output type that
+// takes the provider's `default_batch_size`.
+var batchSizeSettings = map[string]string{
+	httpOutputType: "max_batch_record_count",
+	"s3":           "batch_size",
+}
+
+// applyDefaultBatchSize sets the batch size setting of an output whose
+// configuration omits it to the provider's `default_batch_size`, if any.
+// Settings are not computed, so the default is added to the request rather
+// than planned; withoutDefaultBatchSize keeps it out of state on Read.
+func applyDefaultBatchSize(c *client.Client, outputType string, settings map[string]any) {
+	key, ok := batchSizeSettings[outputType]
+	if !ok || c == nil || c.DefaultBatchSize == 0 || settings[key] != nil {
+		return
+	}
+	settings[key] = c.DefaultBatchSize
+}
+
+// withoutDefaultBatchSize drops a batch size equal to the provider's
+// `default_batch_size` from the settings read back from the API when the
+// configuration omitted it, so the applied default is not reported as drift.
+func withoutDefaultBatchSize(c *client.Client, data *ResourceConnectorModel, apiSettings map[string]any) map[string]any {
+	key, ok := batchSizeSettings[data.ComponentType.ValueString()]
+	if !ok || c == nil || c.DefaultBatchSize == 0 || data.Config == nil {
+		return apiSettings
+	}
+	if n, ok := coerceInt64(apiSettings[key]); !ok || n != c.DefaultBatchSize {
+		return apiSettings
+	}
+	prior, err := tfDynamicToMapAny(data.Config.Settings)
+	if err != nil || prior == nil || prior[key] != nil {
+		return apiSettings
+	}
+
+	trimmed := maps.Clone(apiSettings)
+	delete(trimmed, key)
+	return trimmed
+}
+
+// httpOutputType is the output `type` of the generic HTTP sink.
+const httpOutputType = "http"
+
+// payloadStructureWrapped is the HTTP output `payload_structure` that nests
+// each batch under `wrapper_key`.
+const payloadStructureWrapped = "wrapped"
+
+// expandHTTPHeadersMap rewrites the `headers_map` shorthand of an HTTP output
+// into the `headers` list of `{key, value}` objects the API expects, ordered
+// by key. Settings without `headers_map` are returned unchanged.
+func expandHTTPHeadersMap(settings map[string]any) map[string]any {
+	headers, ok := settings["headers_map"].(map[string]any)
+	if !ok {
+		return settings
+	}
+
+	list := make([]any, 0, len(headers))
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		list = append(list, map[string]any{"key": key, "value": headers[key]})
+	}
+
+	expanded := maps.Clone(settings)
+	delete(expanded, "headers_map")
+	expanded["headers"] = list
+	return expanded
+}
+
+// modifyHTTPOutputPlan warns when an HTTP output's `payload_structure` moves
+// away from "wrapped" while `wrapper_key` is still configured: the key is then
+// ignored by the API but remains in the configuration. Settings are not
+// computed, so the provider cannot drop it from the plan itself; moving to
+// "wrapped" without a key is rejected by ValidateConfig instead.
+func modifyHTTPOutputPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plannedType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &plannedType)...)
+	if resp.Diagnostics.HasError() || plannedType.ValueString() != httpOutputType {
+		return
+	}
+
+	var priorDyn, plannedDyn types.Dynamic
+	if diags := req.State.GetAttribute(ctx, settingsPath, &priorDyn); diags.HasError() {
+		return
+	}
+	if diags := req.Plan.GetAttribute(ctx, settingsPath, &plannedDyn); diags.HasError() {
+		return
+	}
+	if plannedDyn.IsUnknown() || plannedDyn.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	prior, err := tfDynamicToMapAny(priorDyn)
+	if err != nil {
+		return
+	}
+	planned, err := tfDynamicToMapAny(plannedDyn)
+	if err != nil {
+		return
+	}
+
+	priorStructure, _ := prior["payload_structure"].(string)
+	plannedStructure, _ := planned["payload_structure"].(string)
+	if priorStructure != payloadStructureWrapped || plannedStructure == payloadStructureWrapped {
+		return
+	}
+	if wrapperKey, _ := planned["wrapper_key"].(string); wrapperKey == "" {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		settingsPath,
+		"HTTP output wrapper_key will be ignored",
+		fmt.Sprintf(
+			"payload_structure is changing from %q to %q, so records are no longer "+
+				"nested under wrapper_key. Remove wrapper_key from config.settings to "+
+				"keep the configuration accurate.",
+			priorStructure, plannedStructure,
+		),
+	)
+}
+
+// postgresqlOutputType is the output `type` of a PostgreSQL sink.
+const postgresqlOutputType = "postgresql"
+
+// modifyPostgreSQLOutputPlan guards changes to where a PostgreSQL output
+// writes. A new `host` or `database` is a different connection target, so the
+// output is replaced rather than repointed in place; a new `table` (or
+// database) redirects writes, and reordering `column_names` remaps values to
+// columns, both of which are easy to miss in a plan, so they warn.
+// `settings` is a dynamic value, so replacement is requested on the whole of
+// `config.settings`.
+func modifyPostgreSQLOutputPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only updates can redirect writes; creates and destroys have nothing to
+	// compare.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plannedType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &plannedType)...)
+	if resp.Diagnostics.HasError() || plannedType.ValueString() != postgresqlOutputType {
+		return
+	}
+
+	var priorDyn, plannedDyn types.Dynamic
+	if diags := req.State.GetAttribute(ctx, settingsPath, &priorDyn); diags.HasError() {
+		return
+	}
+	if diags := req.Plan.GetAttribute(ctx, settingsPath, &plannedDyn); diags.HasError() {
+		return
+	}
+	if plannedDyn.IsUnknown() {
+		return
+	}
+
+	prior, err := tfDynamicToMapAny(priorDyn)
+	if err != nil {
+		return
+	}
+	planned, err := tfDynamicToMapAny(plannedDyn)
+	if err != nil {
+		return
+	}
+
+	changed := func(key string) bool {
+		return fmt.Sprint(prior[key]) != fmt.Sprint(planned[key])
+	}
+
+	if changed("host") || changed("database") {
+		resp.RequiresReplace = append(resp.RequiresReplace, settingsPath)
+	}
+
+	if columnsReordered(prior["column_names"], planned["column_names"]) {
+		resp.Diagnostics.AddAttributeWarning(
+			settingsPath,
+			"PostgreSQL output column order changed",
+			"`column_names` lists the same columns in a different order. The order is "+
+				"significant: it is the column order used for inserts, so this change "+
+				"alters which column each value is written to.",
+		)
+	}
+
+	if changed("database") || changed("table") {
+		resp.Diagnostics.AddAttributeWarning(
+			settingsPath,
+			"PostgreSQL output will write to a different table",
+			fmt.Sprintf(
+				"This change moves writes from %s to %s. Records already written stay in the "+
+					"old table, and pipelines using this output start writing to the new one "+
+					"as soon as the apply completes.",
+				postgresqlTarget(prior), postgresqlTarget(planned),
+			),
+		)
+	}
+}
+
+// columnsReordered reports whether two `column_names` lists hold the same
+// columns in a different order. Order is significant for inserts, so column
+// lists are compared positionally everywhere else (a server-side reorder
+// surfaces as drift on Read); this only distinguishes a pure reorder, which is
+// easy to mistake for a no-op, from adding or removing columns.
+func columnsReordered(prior, planned any) bool {
+	priorCols, ok := prior.([]any)
+	if !ok {
+		return false
+	}
+	plannedCols, ok := planned.([]any)
+	if !ok || len(priorCols) != len(plannedCols) {
+		return false
+	}
+
+	counts := make(map[string]int, len(priorCols))
+	for _, c := range priorCols {
+		counts[fmt.Sprint(c)]++
+	}
+	reordered := false
+	for i, c := range plannedCols {
+		key := fmt.Sprint(c)
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+		if key != fmt.Sprint(priorCols[i]) {
+			reordered = true
+		}
+	}
+	return reordered
+}
+
+// postgresqlTarget renders the database.table a PostgreSQL output writes to.
+func postgresqlTarget(settings map[string]any) string {
+	database, _ := settings["database"].(string)
+	table, _ := settings["table"].(string)
+	return fmt.Sprintf("%q", database+"."+table)
 }
 
 func (r *ResourceOutput) Delete(
@@ -274,22 +596,17 @@ func (r *ResourceOutput) Delete(
 		return
 	}
 
-	_, monadResp, err := r.client.OrganizationOutputsAPI.
-		V1OrganizationIdOutputsOutputIdDelete(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	monadResp, err := r.client.DeleteOutput(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete output, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete output", err, monadResp)
 		return
 	}
 }
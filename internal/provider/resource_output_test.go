@@ -0,0 +1,807 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// connectorValue builds a raw monad_input/monad_output object with the given
+// type and string-valued config.settings.
+func connectorValue(t *testing.T, s schema.Schema, connectorType string, settings map[string]string) tftypes.Value {
+	t.Helper()
+
+	objType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+
+	settingsTypes := make(map[string]tftypes.Type, len(settings))
+	settingsValues := make(map[string]tftypes.Value, len(settings))
+	for k, v := range settings {
+		settingsTypes[k] = tftypes.String
+		settingsValues[k] = tftypes.NewValue(tftypes.String, v)
+	}
+
+	config := tftypes.NewValue(configType, map[string]tftypes.Value{
+		"settings":     tftypes.NewValue(tftypes.Object{AttributeTypes: settingsTypes}, settingsValues),
+		"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+		"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	return schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "out-1"),
+		"name":   tftypes.NewValue(tftypes.String, "warehouse"),
+		"type":   tftypes.NewValue(tftypes.String, connectorType),
+		"config": config,
+	})
+}
+
+func TestModifyPostgreSQLOutputPlan(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+	settingsPath := path.Root("config").AtName("settings")
+
+	prior := map[string]string{"host": "db-1", "database": "events", "table": "logs"}
+
+	cases := []struct {
+		name        string
+		outputType  string
+		planned     map[string]string
+		wantReplace bool
+		wantWarning bool
+	}{
+		{
+			name:        "table change warns",
+			outputType:  postgresqlOutputType,
+			planned:     map[string]string{"host": "db-1", "database": "events", "table": "audit"},
+			wantWarning: true,
+		},
+		{
+			name:        "host change replaces",
+			outputType:  postgresqlOutputType,
+			planned:     map[string]string{"host": "db-2", "database": "events", "table": "logs"},
+			wantReplace: true,
+		},
+		{
+			name:        "database change replaces and warns",
+			outputType:  postgresqlOutputType,
+			planned:     map[string]string{"host": "db-1", "database": "archive", "table": "logs"},
+			wantReplace: true,
+			wantWarning: true,
+		},
+		{
+			name:       "unrelated output type is ignored",
+			outputType: "http",
+			planned:    map[string]string{"host": "db-2", "database": "archive", "table": "audit"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := connectorValue(t, s, tc.outputType, prior)
+			plan := connectorValue(t, s, tc.outputType, tc.planned)
+
+			req, resp := newModifyPlanRequest(s, plan, plan, state)
+			modifyPostgreSQLOutputPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %s", resp.Diagnostics)
+			}
+
+			replaced := len(resp.RequiresReplace) == 1 && resp.RequiresReplace[0].Equal(settingsPath)
+			if replaced != tc.wantReplace {
+				t.Errorf("expected replace=%v, got RequiresReplace=%v", tc.wantReplace, resp.RequiresReplace)
+			}
+			if warned := resp.Diagnostics.WarningsCount() == 1; warned != tc.wantWarning {
+				t.Errorf("expected warning=%v, got %s", tc.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestValidateConnectorTypeCachesCatalog(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	var catalogCalls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/outputs" {
+			http.NotFound(w, r)
+			return
+		}
+		catalogCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"type_id": "http"},
+			{"type_id": "postgresql"},
+		})
+	})
+	r := &ResourceOutput{client: c}
+
+	plan := func(outputType string) *resource.ModifyPlanResponse {
+		value := connectorValue(t, s, outputType, map[string]string{})
+		req, resp := newModifyPlanRequest(s, value, value, nullSchemaObjectValue(s))
+		r.ModifyPlan(ctx, req, resp)
+		return resp
+	}
+
+	if resp := plan("http"); resp.Diagnostics.HasError() {
+		t.Errorf("known type: unexpected diagnostics: %s", resp.Diagnostics)
+	}
+
+	resp := plan("htpp")
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("unknown type: expected one error, got %s", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "http, postgresql") {
+		t.Errorf("expected the valid types to be listed, got %q", detail)
+	}
+
+	if got := catalogCalls.Load(); got != 1 {
+		t.Errorf("expected the catalog to be fetched once, got %d requests", got)
+	}
+}
+
+func TestValidateConnectorTypeOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"type_id": "http"}})
+	})
+	r := &ResourceOutput{client: c}
+
+	update := func(current, planned string) *resource.ModifyPlanResponse {
+		state := connectorValue(t, s, current, map[string]string{})
+		value := connectorValue(t, s, planned, map[string]string{})
+		req, resp := newModifyPlanRequest(s, value, value, state)
+		r.ModifyPlan(ctx, req, resp)
+		return resp
+	}
+
+	// A type the catalog no longer lists does not block an existing connector.
+	if resp := update("legacy", "legacy"); resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("unchanged type: expected no diagnostics, got %s", resp.Diagnostics)
+	}
+
+	resp := update("http", "htpp")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("changed type: expected a warning only, got %s", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Unknown output type" {
+		t.Errorf("changed type: expected an unknown type warning, got %s", resp.Diagnostics)
+	}
+}
+
+func TestResourceOutputReadMalformedResponse(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "out-1", "name": ` + strings.Repeat(" ", 1024) + `}`))
+	})
+	r := &ResourceOutput{client: c}
+
+	state := connectorValue(t, s, "http", map[string]string{})
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Read(ctx, req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %s", resp.Diagnostics)
+	}
+	d := resp.Diagnostics.Errors()[0]
+	if d.Summary() != "Unexpected API Response" {
+		t.Errorf("unexpected summary %q", d.Summary())
+	}
+	for _, want := range []string{`output "out-1"`, "GET /api/v1/org/outputs/out-1", `{"id": "out-1"`, "(truncated)"} {
+		if !strings.Contains(d.Detail(), want) {
+			t.Errorf("expected detail to contain %q, got %q", want, d.Detail())
+		}
+	}
+}
+
+func TestTruncateSnippet(t *testing.T) {
+	if got := truncateSnippet([]byte("short"), 10); got != "short" {
+		t.Errorf("expected short body to be unchanged, got %q", got)
+	}
+	// The cut lands inside the two-byte "é"; the partial rune is dropped.
+	if got := truncateSnippet([]byte("abcé"), 4); got != "abc... (truncated)" {
+		t.Errorf("unexpected truncation %q", got)
+	}
+}
+
+func TestColumnsReordered(t *testing.T) {
+	cols := func(names ...string) []any {
+		out := make([]any, len(names))
+		for i, n := range names {
+			out[i] = n
+		}
+		return out
+	}
+
+	cases := []struct {
+		name    string
+		prior   any
+		planned any
+		want    bool
+	}{
+		{name: "same order", prior: cols("ts", "host", "msg"), planned: cols("ts", "host", "msg")},
+		{name: "reordered", prior: cols("ts", "host", "msg"), planned: cols("host", "ts", "msg"), want: true},
+		{name: "column added", prior: cols("ts", "host"), planned: cols("host", "ts", "msg")},
+		{name: "column renamed", prior: cols("ts", "host"), planned: cols("host", "time")},
+		{name: "duplicates differ", prior: cols("ts", "ts", "host"), planned: cols("ts", "host", "host")},
+		{name: "not set before", prior: nil, planned: cols("ts")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := columnsReordered(tc.prior, tc.planned); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// column_names order is significant, so a list the server returns in a
+// different order is drift rather than an equivalent value.
+func TestPostgreSQLColumnNamesOrderIsSignificant(t *testing.T) {
+	prior, err := AnyToDynamic(map[string]any{"table": "logs", "column_names": []any{"ts", "host", "msg"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := ResourceConnectorModel{
+		ComponentType: types.StringValue(postgresqlOutputType),
+		Config:        &ResourceConnectorConfig{Settings: prior},
+	}
+	if err := refreshConnectorSettings(&data, map[string]any{"table": "logs", "column_names": []any{"ts", "host", "msg"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !data.Config.Settings.Equal(prior) {
+		t.Errorf("same order: expected prior settings to be kept, got %s", data.Config.Settings)
+	}
+
+	if err := refreshConnectorSettings(&data, map[string]any{"table": "logs", "column_names": []any{"host", "ts", "msg"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := tfDynamicToMapAny(data.Config.Settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dynamicsSemanticallyEqual(map[string]any{"c": got["column_names"]}, map[string]any{"c": []any{"host", "ts", "msg"}}) {
+		t.Errorf("reordered: expected the server order to be stored, got %v", got["column_names"])
+	}
+}
+
+func TestHTTPOutputPayloadStructureTransitions(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+	r := &ResourceOutput{}
+
+	wrapped := map[string]string{"endpoint": "https://hooks.example.com", "payload_structure": payloadStructureWrapped, "wrapper_key": "records"}
+	flat := map[string]string{"endpoint": "https://hooks.example.com", "payload_structure": "ndjson"}
+
+	t.Run("away from wrapped with a dangling wrapper_key warns", func(t *testing.T) {
+		planned := map[string]string{"endpoint": "https://hooks.example.com", "payload_structure": "ndjson", "wrapper_key": "records"}
+		plan := connectorValue(t, s, httpOutputType, planned)
+		req, resp := newModifyPlanRequest(s, plan, plan, connectorValue(t, s, httpOutputType, wrapped))
+		modifyHTTPOutputPlan(ctx, req, resp)
+		if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.HasError() {
+			t.Errorf("expected one warning, got %s", resp.Diagnostics)
+		}
+	})
+
+	t.Run("away from wrapped without wrapper_key is quiet", func(t *testing.T) {
+		plan := connectorValue(t, s, httpOutputType, flat)
+		req, resp := newModifyPlanRequest(s, plan, plan, connectorValue(t, s, httpOutputType, wrapped))
+		modifyHTTPOutputPlan(ctx, req, resp)
+		if len(resp.Diagnostics) != 0 {
+			t.Errorf("expected no diagnostics, got %s", resp.Diagnostics)
+		}
+	})
+
+	t.Run("toward wrapped requires wrapper_key", func(t *testing.T) {
+		planned := map[string]string{"endpoint": "https://hooks.example.com", "payload_structure": payloadStructureWrapped}
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: connectorValue(t, s, httpOutputType, planned)},
+		}, resp)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Missing wrapper_key" {
+			t.Errorf("expected a missing wrapper_key error, got %s", resp.Diagnostics)
+		}
+	})
+
+	t.Run("toward wrapped with wrapper_key is valid", func(t *testing.T) {
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: connectorValue(t, s, httpOutputType, wrapped)},
+		}, resp)
+		plan := connectorValue(t, s, httpOutputType, wrapped)
+		req, planResp := newModifyPlanRequest(s, plan, plan, connectorValue(t, s, httpOutputType, flat))
+		modifyHTTPOutputPlan(ctx, req, planResp)
+		if len(resp.Diagnostics) != 0 || len(planResp.Diagnostics) != 0 {
+			t.Errorf("expected no diagnostics, got %s %s", resp.Diagnostics, planResp.Diagnostics)
+		}
+	})
+}
+
+func TestResourceOutputReadResolvedType(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	// The server canonicalizes the configured "S3" to "s3".
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "out-1", "name": "warehouse", "type": "s3", "config": {"settings": {}}}`))
+	})
+	r := &ResourceOutput{client: c}
+
+	for _, tt := range []struct {
+		name      string
+		priorType string
+		wantType  string
+	}{
+		{name: "configured spelling is kept", priorType: "S3", wantType: "S3"},
+		{name: "import takes the api type", wantType: "s3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := connectorValue(t, s, tt.priorType, map[string]string{})
+			if tt.priorType == "" {
+				state = schemaObjectValue(t, s, map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "out-1")})
+			}
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+			r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var componentType, resolvedType types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("type"), &componentType)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("resolved_type"), &resolvedType)...)
+			if componentType.ValueString() != tt.wantType {
+				t.Errorf("expected type %q, got %s", tt.wantType, componentType)
+			}
+			if resolvedType.ValueString() != "s3" {
+				t.Errorf("expected resolved_type s3, got %s", resolvedType)
+			}
+		})
+	}
+}
+
+func TestModifyConnectorPlanForResolvedType(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	withResolved := func(v tftypes.Value, resolved any) tftypes.Value {
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			t.Fatal(err)
+		}
+		attrs["resolved_type"] = tftypes.NewValue(tftypes.String, resolved)
+		return tftypes.NewValue(v.Type(), attrs)
+	}
+	state := withResolved(connectorValue(t, s, "S3", map[string]string{}), "s3")
+
+	for _, tt := range []struct {
+		name        string
+		planType    string
+		wantUnknown bool
+	}{
+		{name: "unchanged type keeps resolved_type", planType: "S3"},
+		{name: "changed type recomputes resolved_type", planType: "gcs", wantUnknown: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := withResolved(connectorValue(t, s, tt.planType, map[string]string{}), tftypes.UnknownValue)
+			req, resp := newModifyPlanRequest(s, plan, plan, state)
+			modifyConnectorPlanForResolvedType(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("resolved_type"), &got)...)
+			if got.IsUnknown() != tt.wantUnknown {
+				t.Errorf("expected unknown=%t, got %s", tt.wantUnknown, got)
+			}
+			if !tt.wantUnknown && got.ValueString() != "s3" {
+				t.Errorf("expected resolved_type s3, got %s", got)
+			}
+		})
+	}
+}
+
+func TestValidateConnectorTypeCatalogUnavailable(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "catalog is down", http.StatusInternalServerError)
+	})
+	r := &ResourceOutput{client: c}
+
+	plan := func(outputType string) *resource.ModifyPlanResponse {
+		value := connectorValue(t, s, outputType, map[string]string{})
+		req, resp := newModifyPlanRequest(s, value, value, nullSchemaObjectValue(s))
+		r.ModifyPlan(ctx, req, resp)
+		return resp
+	}
+
+	resp := plan("htpp")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected the plan to proceed, got %s", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Connector catalog unavailable" {
+		t.Fatalf("expected a catalog warning, got %s", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Warnings()[0].Detail(); !strings.Contains(detail, "catalog is down") {
+		t.Errorf("expected the response body in the warning, got %q", detail)
+	}
+
+	// The warning is not repeated for every connector in the plan.
+	if resp := plan("http"); resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("second plan: expected no diagnostics, got %s", resp.Diagnostics)
+	}
+}
+
+func TestDefaultBatchSize(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	var sent map[string]any
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Config struct {
+				Settings map[string]any `json:"settings"`
+			} `json:"config"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sent = body.Config.Settings
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "out-1", "type": "http"}`))
+	})
+	c.DefaultBatchSize = 500
+	r := &ResourceOutput{client: c}
+
+	create := func(outputType string, settings map[string]string) map[string]any {
+		sent = nil
+		value := connectorValue(t, s, outputType, settings)
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+		r.Create(ctx, resource.CreateRequest{
+			Config: tfsdk.Config{Schema: s, Raw: value},
+			Plan:   tfsdk.Plan{Schema: s, Raw: value},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics %s", outputType, resp.Diagnostics)
+		}
+		return sent
+	}
+
+	if got := create("http", map[string]string{"endpoint": "https://example.com"}); got["max_batch_record_count"] != float64(500) {
+		t.Errorf("http: expected the default batch size, got %v", got)
+	}
+	if got := create("s3", map[string]string{"bucket": "logs"}); got["batch_size"] != float64(500) {
+		t.Errorf("s3: expected the default batch size, got %v", got)
+	}
+	if got := create("s3", map[string]string{"bucket": "logs", "batch_size": "100"}); got["batch_size"] != "100" {
+		t.Errorf("s3: expected the configured batch size to win, got %v", got)
+	}
+	if got := create("postgresql", map[string]string{"table": "raw"}); len(got) != 1 {
+		t.Errorf("postgresql: expected no batch size to be added, got %v", got)
+	}
+
+	// Reading back the applied default is not drift.
+	state := connectorValue(t, s, "http", map[string]string{"endpoint": "https://example.com"})
+	var data ResourceConnectorModel
+	if diags := (tfsdk.State{Schema: s, Raw: state}).Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	prior := data.Config.Settings
+	apiSettings := withoutDefaultBatchSize(c, &data, map[string]any{
+		"endpoint":               "https://example.com",
+		"max_batch_record_count": float64(500),
+	})
+	if err := refreshConnectorSettings(&data, apiSettings); err != nil {
+		t.Fatal(err)
+	}
+	if !data.Config.Settings.Equal(prior) {
+		t.Errorf("expected the applied default not to show as drift, got %s", data.Config.Settings)
+	}
+}
+
+func TestResourceOutputImportNestedSettings(t *testing.T) {
+	ctx := context.Background()
+
+	body := `{"id": "out-1", "name": "warehouse", "type": "http", "config": {"settings": {
+		"endpoint": "https://hooks.example.com",
+		"headers": [{"key": "X-Team", "value": "secops"}],
+		"retry": {"max_attempts": 3, "backoff": "1s"}
+	}}}`
+	r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})}
+	s := resourceSchema(t, r)
+
+	importResp := &resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "out-1"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import: unexpected diagnostics: %s", importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read: unexpected diagnostics: %s", readResp.Diagnostics)
+	}
+
+	var data ResourceConnectorModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	if data.ComponentType.ValueString() != httpOutputType || data.ResolvedType.ValueString() != httpOutputType {
+		t.Fatalf("expected type and resolved_type http, got %s and %s", data.ComponentType, data.ResolvedType)
+	}
+
+	// The configuration written to match the imported output, as HCL decodes
+	// it, must equal the imported settings or the next plan shows a diff.
+	headerType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"key": tftypes.String, "value": tftypes.String}}
+	retryType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"max_attempts": tftypes.Number, "backoff": tftypes.String}}
+	headersType := tftypes.Tuple{ElementTypes: []tftypes.Type{headerType}}
+	configured := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint": tftypes.String,
+		"headers":  headersType,
+		"retry":    retryType,
+	}}, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://hooks.example.com"),
+		"headers": tftypes.NewValue(headersType, []tftypes.Value{
+			tftypes.NewValue(headerType, map[string]tftypes.Value{
+				"key":   tftypes.NewValue(tftypes.String, "X-Team"),
+				"value": tftypes.NewValue(tftypes.String, "secops"),
+			}),
+		}),
+		"retry": tftypes.NewValue(retryType, map[string]tftypes.Value{
+			"max_attempts": tftypes.NewValue(tftypes.Number, 3),
+			"backoff":      tftypes.NewValue(tftypes.String, "1s"),
+		}),
+	})
+	var settings types.Dynamic
+	readResp.Diagnostics.Append(readResp.State.GetAttribute(ctx, path.Root("config").AtName("settings"), &settings)...)
+	imported, err := settings.ToTerraformValue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !imported.Equal(configured) {
+		t.Errorf("imported settings differ from the configuration:\n got: %s\nwant: %s", imported, configured)
+	}
+
+	// A refresh of the imported state is stable.
+	refreshResp := &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, refreshResp)
+	if refreshResp.Diagnostics.HasError() || !refreshResp.State.Raw.Equal(readResp.State.Raw) {
+		t.Errorf("expected a stable refresh, got %s (diagnostics: %s)", refreshResp.State.Raw, refreshResp.Diagnostics)
+	}
+
+	t.Run("type missing from the API", func(t *testing.T) {
+		r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": "out-1", "name": "warehouse", "config": {"settings": {"endpoint": "https://hooks.example.com"}}}`))
+		})}
+		readResp := &resource.ReadResponse{State: importResp.State}
+		r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+		if readResp.Diagnostics.ErrorsCount() != 1 || readResp.Diagnostics.Errors()[0].Summary() != "Unable to determine output type" {
+			t.Errorf("expected a missing type error, got %s", readResp.Diagnostics)
+		}
+	})
+}
+
+func TestHTTPOutputReadToleratesSparseSettings(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	headersMapType := tftypes.Map{ElementType: tftypes.String}
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint":    tftypes.String,
+		"headers_map": headersMapType,
+	}}
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+	withHeadersMap := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "out-1"),
+		"name": tftypes.NewValue(tftypes.String, "warehouse"),
+		"type": tftypes.NewValue(tftypes.String, httpOutputType),
+		"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+			"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, "https://hooks.example.com"),
+				"headers_map": tftypes.NewValue(headersMapType, map[string]tftypes.Value{
+					"X-Team": tftypes.NewValue(tftypes.String, "secops"),
+				}),
+			}),
+			"secrets":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			"secrets_hash": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+	endpointOnly := connectorValue(t, s, httpOutputType, map[string]string{"endpoint": "https://hooks.example.com"})
+
+	for _, tc := range []struct {
+		name  string
+		state tftypes.Value
+		body  string
+	}{
+		{
+			name:  "endpoint only",
+			state: endpointOnly,
+			body:  `{"id": "out-1", "name": "warehouse", "type": "http", "config": {"settings": {"endpoint": "https://hooks.example.com"}}}`,
+		},
+		{
+			name:  "server defaults with other JSON types",
+			state: endpointOnly,
+			body: `{"id": "out-1", "name": "warehouse", "type": "http", "config": {"settings": {
+				"endpoint": "https://hooks.example.com", "method": null, "max_batch_data_size": 1048576,
+				"headers": [{"key": "X-Team", "value": "secops"}]}}}`,
+		},
+		{
+			name:  "headers_map against a headers list",
+			state: withHeadersMap,
+			body: `{"id": "out-1", "name": "warehouse", "type": "http", "config": {"settings": {
+				"endpoint": "https://hooks.example.com", "headers": [{"key": "X-Team", "value": "secops"}]}}}`,
+		},
+		{
+			name:  "headers in an unexpected shape",
+			state: withHeadersMap,
+			body: `{"id": "out-1", "name": "warehouse", "type": "http", "config": {"settings": {
+				"endpoint": "https://hooks.example.com", "headers": {"X-Team": "secops"}}}}`,
+		},
+		{
+			name:  "no config",
+			state: endpointOnly,
+			body:  `{"id": "out-1", "name": "warehouse", "type": "http"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.body))
+			})}
+			state := tfsdk.State{Schema: s, Raw: tc.state}
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// Updates send the configured type, so a PostgreSQL output is never relabelled
+// as another output type server-side.
+func TestResourceOutputUpdateSendsConfiguredType(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+
+	var method, outputType string
+	r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			OutputType string `json:"output_type"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		method, outputType = req.Method, body.OutputType
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "out-1", "type": "postgresql"}`))
+	})}
+
+	value := connectorValue(t, s, postgresqlOutputType, map[string]string{"table": "raw"})
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: value}}
+	r.Update(ctx, resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: value},
+		Plan:   tfsdk.Plan{Schema: s, Raw: value},
+		State:  tfsdk.State{Schema: s, Raw: value},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+	}
+	if method != http.MethodPut || outputType != postgresqlOutputType {
+		t.Errorf("expected a PUT with output_type %q, got %s with %q", postgresqlOutputType, method, outputType)
+	}
+}
+
+// auth_headers are write-only secrets the API never returns. What carries them
+// across a refresh is secrets_hash, which Read keeps; an unrelated update
+// re-sends them from config, so the stored headers are never dropped.
+func TestHTTPOutputAuthHeadersSurviveRefreshAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceOutput{})
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	configType := objType.AttributeTypes["config"].(tftypes.Object)
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint": tftypes.String,
+		"method":   tftypes.String,
+	}}
+	authHeadersType := tftypes.Map{ElementType: tftypes.String}
+	secretsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"auth_headers": authHeadersType}}
+
+	var sentSecrets map[string]any
+	r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut {
+			var body struct {
+				Config struct {
+					Secrets map[string]any `json:"secrets"`
+				} `json:"config"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			sentSecrets = body.Config.Secrets
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Secrets are not returned.
+		_, _ = w.Write([]byte(`{"id": "out-1", "name": "siem", "type": "http",
+			"config": {"settings": {"endpoint": "https://siem.example.com", "method": "POST"}}}`))
+	})}
+
+	authHeaders := map[string]any{"auth_headers": map[string]any{"Authorization": "Bearer t-123"}}
+	storedHash, err := computeSecretsHash(ctx, "org", authHeaders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connector := func(method string, secrets tftypes.Value, hash any) tftypes.Value {
+		return schemaObjectValue(t, s, map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "out-1"),
+			"name":            tftypes.NewValue(tftypes.String, "siem"),
+			"type":            tftypes.NewValue(tftypes.String, httpOutputType),
+			"resolved_type":   tftypes.NewValue(tftypes.String, httpOutputType),
+			"organization_id": tftypes.NewValue(tftypes.String, "org"),
+			"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+				"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+					"endpoint": tftypes.NewValue(tftypes.String, "https://siem.example.com"),
+					"method":   tftypes.NewValue(tftypes.String, method),
+				}),
+				"secrets":      secrets,
+				"secrets_hash": tftypes.NewValue(tftypes.String, hash),
+			}),
+		})
+	}
+	noSecrets := tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+	configuredSecrets := tftypes.NewValue(secretsType, map[string]tftypes.Value{
+		"auth_headers": tftypes.NewValue(authHeadersType, map[string]tftypes.Value{
+			"Authorization": tftypes.NewValue(tftypes.String, "Bearer t-123"),
+		}),
+	})
+
+	state := connector("POST", noSecrets, storedHash)
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state.Copy()}}
+	r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read: unexpected diagnostics %s", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(state) {
+		t.Fatalf("expected the refresh to leave state unchanged, got %s", readResp.State.Raw)
+	}
+
+	// Changing only the method keeps the stored auth headers.
+	plan := connector("PUT", noSecrets, storedHash)
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: plan}}
+	r.Update(ctx, resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: connector("PUT", configuredSecrets, nil)},
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+		State:  readResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update: unexpected diagnostics %s", updateResp.Diagnostics)
+	}
+	if !reflect.DeepEqual(sentSecrets, authHeaders) {
+		t.Errorf("expected the auth headers to be re-sent, got %v", sentSecrets)
+	}
+	var hash types.String
+	updateResp.Diagnostics.Append(updateResp.State.GetAttribute(ctx, path.Root("config").AtName("secrets_hash"), &hash)...)
+	if hash.ValueString() != storedHash {
+		t.Errorf("expected secrets_hash to stay %s, got %s", storedHash, hash)
+	}
+}
@@ -2,11 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"math/big"
+	"net/http"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,32 +31,38 @@ import (
 var _ resource.Resource = &ResourcePipeline{}
 var _ resource.ResourceWithConfigure = &ResourcePipeline{}
 var _ resource.ResourceWithImportState = &ResourcePipeline{}
+var _ resource.ResourceWithModifyPlan = &ResourcePipeline{}
+var _ resource.ResourceWithValidateConfig = &ResourcePipeline{}
 
 type ResourcePipeline struct {
 	client *client.Client
 }
 
 type ResourcePipelineModel struct {
-	ID          types.String           `tfsdk:"id"`
-	Name        types.String           `tfsdk:"name"`
-	Description types.String           `tfsdk:"description"`
-	Nodes       []ResourcePipelineNode `tfsdk:"nodes"`
-	Edges       []ResourcePipelineEdge `tfsdk:"edges"`
-	Enabled     types.Bool             `tfsdk:"enabled"`
+	ID             types.String           `tfsdk:"id"`
+	Name           types.String           `tfsdk:"name"`
+	Description    types.String           `tfsdk:"description"`
+	Nodes          []ResourcePipelineNode `tfsdk:"nodes"`
+	Edges          []ResourcePipelineEdge `tfsdk:"edges"`
+	Enabled        types.Bool             `tfsdk:"enabled"`
+	OrganizationID types.String           `tfsdk:"organization_id"`
+	Timeouts       *ResourceTimeouts      `tfsdk:"timeouts"`
 }
 
 type ResourcePipelineNode struct {
 	ComponentType types.String `tfsdk:"component_type"`
 	ComponentID   types.String `tfsdk:"component_id"`
 	Slug          types.String `tfsdk:"slug"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
 }
 
 type ResourcePipelineEdge struct {
-	Name                 types.String              `tfsdk:"name"`
-	Description          types.String              `tfsdk:"description"`
-	FromNodeInstanceSlug types.String              `tfsdk:"from_node_instance_slug"`
-	ToNodeInstanceSlug   types.String              `tfsdk:"to_node_instance_slug"`
-	Condition            ResourcePipelineCondition `tfsdk:"condition"`
+	ID                   types.String               `tfsdk:"id"`
+	Name                 types.String               `tfsdk:"name"`
+	Description          types.String               `tfsdk:"description"`
+	FromNodeInstanceSlug types.String               `tfsdk:"from_node_instance_slug"`
+	ToNodeInstanceSlug   types.String               `tfsdk:"to_node_instance_slug"`
+	Condition            *ResourcePipelineCondition `tfsdk:"condition"`
 }
 
 type ResourcePipelineCondition struct {
@@ -61,9 +76,11 @@ type ResourcePipelineConditionCondition struct {
 }
 
 type ResourcePipelineConditionConditionConfig struct {
-	Key   types.String `tfsdk:"key"`
-	Value types.List   `tfsdk:"value"`
-	Rate  types.String `tfsdk:"rate"`
+	Key           types.String `tfsdk:"key"`
+	Value         types.List   `tfsdk:"value"`
+	Rate          types.String `tfsdk:"rate"`
+	Threshold     types.Number `tfsdk:"threshold"`
+	CaseSensitive types.Bool   `tfsdk:"case_sensitive"`
 }
 
 func NewResourcePipeline() resource.Resource {
@@ -124,13 +141,24 @@ func (r *ResourcePipeline) Schema(
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the pipeline",
 				Optional:            true,
+				Computed:            true,
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether the pipeline is enabled",
 				Optional:            true,
 			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization the pipeline belongs to. Defaults to the provider's `organization_id`; set by import when using the `org_id/pipeline_id` form.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 			"nodes": schema.ListNestedBlock{
 				MarkdownDescription: "List of nodes in the pipeline",
 				NestedObject: schema.NestedBlockObject{
@@ -147,6 +175,10 @@ func (r *ResourcePipeline) Schema(
 							MarkdownDescription: "Slug for the node",
 							Optional:            true,
 						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the node is enabled. Defaults to `true`; set to `false` to pause the node without removing it from the pipeline.",
+							Optional:            true,
+						},
 					},
 				},
 			},
@@ -154,6 +186,10 @@ func (r *ResourcePipeline) Schema(
 				MarkdownDescription: "List of edges in the pipeline",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Server-assigned identifier of the edge",
+							Computed:            true,
+						},
 						"name": schema.StringAttribute{
 							MarkdownDescription: "Name of the edge",
 							Optional:            true,
@@ -207,6 +243,14 @@ func (r *ResourcePipeline) Schema(
 														MarkdownDescription: "The rate at which records should be passed through the condition. Example: '100ms', '1s', '1m'",
 														Optional:            true,
 													},
+													"threshold": schema.NumberAttribute{
+														MarkdownDescription: "Numeric threshold for condition types that compare against a number. Sent to the API as a JSON number, separately from `rate`.",
+														Optional:            true,
+													},
+													"case_sensitive": schema.BoolAttribute{
+														MarkdownDescription: "Whether `value` is compared case-sensitively. Defaults to the server's behavior when omitted.",
+														Optional:            true,
+													},
 												},
 											},
 										},
@@ -221,6 +265,23 @@ func (r *ResourcePipeline) Schema(
 	}
 }
 
+// requireKnownPipeline checks the pipeline attributes sent to the API with
+// requireKnown. Computed attributes such as edges[].id are left out; they are
+// expected to be unknown in an update plan.
+func requireKnownPipeline(ctx context.Context, diags *diag.Diagnostics, m *ResourcePipelineModel) {
+	requireKnown(ctx, diags, path.Root("name"), m.Name)
+	for i, node := range m.Nodes {
+		nodePath := path.Root("nodes").AtListIndex(i)
+		requireKnown(ctx, diags, nodePath.AtName("component_type"), node.ComponentType)
+		requireKnown(ctx, diags, nodePath.AtName("component_id"), node.ComponentID)
+	}
+	for i, edge := range m.Edges {
+		edgePath := path.Root("edges").AtListIndex(i)
+		requireKnown(ctx, diags, edgePath.AtName("from_node_instance_slug"), edge.FromNodeInstanceSlug)
+		requireKnown(ctx, diags, edgePath.AtName("to_node_instance_slug"), edge.ToNodeInstanceSlug)
+	}
+}
+
 // buildPipelineRequestNodes/Edges translate the plan model into the SDK request
 // shape shared by Create and Update.
 func buildPipelineRequestNodes(nodes []ResourcePipelineNode) []monad.RoutesV2PipelineRequestNode {
@@ -230,7 +291,7 @@ func buildPipelineRequestNodes(nodes []ResourcePipelineNode) []monad.RoutesV2Pip
 			ComponentType: node.ComponentType.ValueString(),
 			ComponentId:   node.ComponentID.ValueString(),
 			Slug:          node.Slug.ValueStringPointer(),
-			Enabled:       true,
+			Enabled:       node.Enabled.IsNull() || node.Enabled.ValueBool(),
 		}
 	}
 	return out
@@ -244,11 +305,15 @@ func buildPipelineRequestEdges(ctx context.Context, edges []ResourcePipelineEdge
 			Description:        edge.Description.ValueStringPointer(),
 			FromNodeInstanceId: edge.FromNodeInstanceSlug.ValueString(),
 			ToNodeInstanceId:   edge.ToNodeInstanceSlug.ValueString(),
-			Conditions: &monad.ModelsPipelineEdgeConditions{
-				Operator: edge.Condition.Operator.ValueStringPointer(),
-			},
 		}
 
+		// An edge without a condition block is unconditional.
+		if edge.Condition == nil {
+			continue
+		}
+		out[i].Conditions = &monad.ModelsPipelineEdgeConditions{
+			Operator: edge.Condition.Operator.ValueStringPointer(),
+		}
 		if len(edge.Condition.Conditions) == 0 {
 			continue
 		}
@@ -262,13 +327,25 @@ func buildPipelineRequestEdges(ctx context.Context, edges []ResourcePipelineEdge
 				}
 			}
 
+			config := map[string]any{
+				"key":   condition.Config.Key.ValueString(),
+				"value": values,
+			}
+			// rate and case_sensitive are omitted when unset so the server
+			// applies its own default; an empty rate would not read back.
+			if !condition.Config.Rate.IsNull() && !condition.Config.Rate.IsUnknown() {
+				config["rate"] = condition.Config.Rate.ValueString()
+			}
+			if !condition.Config.Threshold.IsNull() && !condition.Config.Threshold.IsUnknown() {
+				config["threshold"] = thresholdToAPI(condition.Config.Threshold)
+			}
+			if !condition.Config.CaseSensitive.IsNull() && !condition.Config.CaseSensitive.IsUnknown() {
+				config["case_sensitive"] = condition.Config.CaseSensitive.ValueBool()
+			}
+
 			out[i].Conditions.Conditions[j] = monad.ModelsPipelineEdgeCondition{
 				TypeId: condition.TypeID.ValueStringPointer(),
-				Config: map[string]any{
-					"key":   condition.Config.Key.ValueString(),
-					"value": values,
-					"rate":  condition.Config.Rate.ValueString(),
-				},
+				Config: config,
 			}
 		}
 	}
@@ -283,6 +360,19 @@ func (r *ResourcePipeline) Create(
 	var data ResourcePipelineModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireKnownPipeline(ctx, &resp.Diagnostics, &data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -306,20 +396,11 @@ func (r *ResourcePipeline) Create(
 		Edges:       edges,
 	}
 
-	pipeline, monadResp, err := r.client.PipelinesAPI.V2OrganizationIdPipelinesPost(
-		ctx,
-		r.client.OrganizationID,
-	).RoutesV2CreatePipelineRequest(request).
-		Execute()
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	pipeline, monadResp, err := r.client.CreatePipeline(ctx, organizationID, request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create pipeline, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create pipeline", err, monadResp)
 		return
 	}
 
@@ -329,8 +410,17 @@ func (r *ResourcePipeline) Create(
 	// representation differences — nullable edge name/description, omitted node
 	// slugs, node ordering, server-generated node-instance ids — that trip
 	// "Provider produced inconsistent result after apply" and cause perpetual
-	// diffs.
+	// diffs. Edge ids are computed, so they are the one exception.
 	data.ID = types.StringValue(*pipeline.Id)
+	data.OrganizationID = types.StringValue(organizationID)
+
+	if settled, monadResp, err := awaitPipelineNodeIDs(ctx, r.client, organizationID, pipeline); err != nil {
+		// The pipeline exists; keep it in state so it is not orphaned.
+		addClientError(r.client, &resp.Diagnostics, "read created pipeline", err, monadResp)
+	} else {
+		pipeline = settled
+	}
+	assignPipelineEdgeIDs(data.Edges, pipeline)
 
 	tflog.Trace(ctx, "created a pipeline resource")
 
@@ -349,33 +439,24 @@ func (r *ResourcePipeline) Read(
 		return
 	}
 
-	pipeline, monadResp, err := r.client.PipelinesAPI.
-		V2OrganizationIdPipelinesPipelineIdGet(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read pipeline, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	description := types.StringNull()
-	if pipeline.Description != nil && *pipeline.Description != "" {
-		description = types.StringValue(*pipeline.Description)
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	pipeline, monadResp, err := r.client.GetPipeline(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(r.client, &resp.Diagnostics, "pipeline", data.ID.ValueString(), err, monadResp)
+		return
 	}
 
 	data.ID = types.StringValue(*pipeline.Id)
 	data.Name = types.StringValue(*pipeline.Name)
-	data.Description = description
+	data.Description = refreshDescription(data.Description, pipeline.Description)
+	data.OrganizationID = types.StringValue(organizationID)
 
 	// Refresh `enabled` so a pipeline toggled outside Terraform (e.g. in the UI)
 	// surfaces as drift in the next plan.
@@ -409,6 +490,8 @@ func buildPipelineStateNodes(pipeline *monad.ModelsPipelineConfigV2, priorNodes
 			ComponentType: types.StringPointerValue(node.ComponentType),
 			ComponentID:   types.StringPointerValue(node.ComponentId),
 			Slug:          slug,
+			// A node the API reports without `enabled` runs, as on create.
+			Enabled: types.BoolValue(node.Enabled == nil || *node.Enabled),
 		}
 	}
 	sortNodesByConfigOrder(nodes, priorNodes)
@@ -430,11 +513,12 @@ func buildPipelineStateEdges(pipeline *monad.ModelsPipelineConfigV2, priorEdges
 			description = types.StringValue(*edge.Description)
 		}
 
-		operator := types.StringNull()
-		conditions := []ResourcePipelineConditionCondition{}
-		if edge.Conditions != nil {
-			operator = types.StringPointerValue(edge.Conditions.Operator)
-			conditions = make([]ResourcePipelineConditionCondition, len(edge.Conditions.Conditions))
+		// Unconditional edges come back without conditions, or with neither
+		// an operator nor any conditions; both read as an absent block.
+		var condition *ResourcePipelineCondition
+		if edge.Conditions != nil && (edge.Conditions.Operator != nil || len(edge.Conditions.Conditions) > 0) {
+			operator := types.StringPointerValue(edge.Conditions.Operator)
+			conditions := make([]ResourcePipelineConditionCondition, len(edge.Conditions.Conditions))
 			for j, condition := range edge.Conditions.Conditions {
 				key := types.StringNull()
 				if k, ok := condition.Config["key"].(string); ok && k != "" {
@@ -457,15 +541,26 @@ func buildPipelineStateEdges(pipeline *monad.ModelsPipelineConfigV2, priorEdges
 					value = types.ListValueMust(types.StringType, values)
 				}
 
+				caseSensitive := types.BoolNull()
+				if cs, ok := condition.Config["case_sensitive"].(bool); ok {
+					caseSensitive = types.BoolValue(cs)
+				}
+
 				conditions[j] = ResourcePipelineConditionCondition{
 					TypeID: types.StringPointerValue(condition.TypeId),
 					Config: ResourcePipelineConditionConditionConfig{
-						Key:   key,
-						Value: value,
-						Rate:  rate,
+						Key:           key,
+						Value:         value,
+						Rate:          rate,
+						Threshold:     thresholdFromAPI(condition.Config["threshold"]),
+						CaseSensitive: caseSensitive,
 					},
 				}
 			}
+			condition = &ResourcePipelineCondition{
+				Operator:   operator,
+				Conditions: conditions,
+			}
 		}
 
 		fromSlug := ""
@@ -478,14 +573,12 @@ func buildPipelineStateEdges(pipeline *monad.ModelsPipelineConfigV2, priorEdges
 		}
 
 		edges[i] = ResourcePipelineEdge{
+			ID:                   types.StringPointerValue(edge.Id),
 			Name:                 name,
 			Description:          description,
 			FromNodeInstanceSlug: types.StringValue(fromSlug),
 			ToNodeInstanceSlug:   types.StringValue(toSlug),
-			Condition: ResourcePipelineCondition{
-				Operator:   operator,
-				Conditions: conditions,
-			},
+			Condition:            condition,
 		}
 	}
 	sortEdgesByConfigOrder(edges, priorEdges)
@@ -501,18 +594,105 @@ func getSlugForNodeID(nodes []monad.ModelsPipelineNode, nodeID string) string {
 	return ""
 }
 
+// pipelineNodeIDAttempts bounds how many times awaitPipelineNodeIDs re-reads
+// a pipeline whose nodes have not been assigned ids and slugs yet.
+const pipelineNodeIDAttempts = 5
+
+// pipelineNodeIDsPending reports whether an edge of pipeline references a node
+// the API has not finished materializing, so its endpoints would map to
+// empty slugs.
+func pipelineNodeIDsPending(pipeline *monad.ModelsPipelineConfigV2) bool {
+	for _, edge := range pipeline.Edges {
+		if edge.FromNodeInstanceId == nil || edge.ToNodeInstanceId == nil ||
+			getSlugForNodeID(pipeline.Nodes, *edge.FromNodeInstanceId) == "" ||
+			getSlugForNodeID(pipeline.Nodes, *edge.ToNodeInstanceId) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// awaitPipelineNodeIDs returns pipeline once its edges resolve to node slugs.
+// The API may assign node ids and slugs asynchronously after a create or
+// update, so the pipeline is re-read with the client's retry backoff until
+// they appear. When they never do, the last response is returned and its
+// edges are left without ids.
+func awaitPipelineNodeIDs(
+	ctx context.Context,
+	c *client.Client,
+	organizationID string,
+	pipeline *monad.ModelsPipelineConfigV2,
+) (*monad.ModelsPipelineConfigV2, *http.Response, error) {
+	wait := c.RetryWaitMin
+	for attempt := 0; attempt < pipelineNodeIDAttempts && pipelineNodeIDsPending(pipeline); attempt++ {
+		tflog.Debug(ctx, "pipeline nodes have no ids yet, re-reading the pipeline", map[string]any{
+			"pipeline_id": pipeline.GetId(),
+			"attempt":     attempt + 1,
+		})
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait = min(2*wait, c.RetryWaitMax)
+
+		refreshed, monadResp, err := c.GetPipeline(ctx, organizationID, pipeline.GetId())
+		if err != nil {
+			return nil, monadResp, err
+		}
+		pipeline = refreshed
+	}
+
+	if pipelineNodeIDsPending(pipeline) {
+		tflog.Warn(ctx, "pipeline nodes still have no ids; edge ids are left unset until the next refresh", map[string]any{
+			"pipeline_id": pipeline.GetId(),
+		})
+	}
+	return pipeline, nil, nil
+}
+
+// sortNodesByConfigOrder sorts nodes into the order of configNodes. Each
+// config node is claimed by at most one node, first by slug and then by
+// component_id, so nodes that share a component or have no slug keep distinct,
+// stable positions instead of colliding on one key. Nodes matching no config
+// node sort last, by component_id and slug.
 func sortNodesByConfigOrder(nodes []ResourcePipelineNode, configNodes []ResourcePipelineNode) {
-	configOrder := make(map[string]int)
-	for i, node := range configNodes {
-		configOrder[node.ComponentID.ValueString()] = i
+	order := make([]int, len(nodes))
+	claimed := make(map[int]bool, len(configNodes))
+	claim := func(i int, matches func(ResourcePipelineNode) bool) {
+		for o, configNode := range configNodes {
+			if !claimed[o] && matches(configNode) {
+				order[i] = o
+				claimed[o] = true
+				return
+			}
+		}
+	}
+
+	for i, node := range nodes {
+		order[i] = -1
+		if slug := node.Slug.ValueString(); slug != "" {
+			claim(i, func(c ResourcePipelineNode) bool { return c.Slug.ValueString() == slug })
+		}
+	}
+	for i, node := range nodes {
+		if order[i] == -1 {
+			claim(i, func(c ResourcePipelineNode) bool {
+				return c.ComponentID.ValueString() == node.ComponentID.ValueString()
+			})
+		}
 	}
 
-	sort.SliceStable(nodes, func(i, j int) bool {
-		orderI, okI := configOrder[nodes[i].ComponentID.ValueString()]
-		orderJ, okJ := configOrder[nodes[j].ComponentID.ValueString()]
+	indexes := make([]int, len(nodes))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		i, j := indexes[a], indexes[b]
+		okI, okJ := order[i] != -1, order[j] != -1
 
 		if okI && okJ {
-			return orderI < orderJ
+			return order[i] < order[j]
 		}
 		if okI {
 			return true
@@ -520,28 +700,62 @@ func sortNodesByConfigOrder(nodes []ResourcePipelineNode, configNodes []Resource
 		if okJ {
 			return false
 		}
-		return nodes[i].ComponentID.ValueString() < nodes[j].ComponentID.ValueString()
+		if nodes[i].ComponentID.ValueString() != nodes[j].ComponentID.ValueString() {
+			return nodes[i].ComponentID.ValueString() < nodes[j].ComponentID.ValueString()
+		}
+		return nodes[i].Slug.ValueString() < nodes[j].Slug.ValueString()
 	})
+
+	sorted := make([]ResourcePipelineNode, len(nodes))
+	for i, idx := range indexes {
+		sorted[i] = nodes[idx]
+	}
+	copy(nodes, sorted)
 }
 
+// sortEdgesByConfigOrder sorts API edges to match the prior config order,
+// matching edges by their from->to slug pair. Edge ids are issued by the
+// server and are not part of the config, so they are never used to match.
+// Parallel edges share a slug pair, so they are pre-sorted by id and claim the
+// matching config positions in turn; this keeps their order stable across
+// reads instead of depending on the order the API happens to return them in.
 func sortEdgesByConfigOrder(edges []ResourcePipelineEdge, configEdges []ResourcePipelineEdge) {
 	edgeKey := func(e ResourcePipelineEdge) string {
 		return e.FromNodeInstanceSlug.ValueString() + "->" + e.ToNodeInstanceSlug.ValueString()
 	}
 
-	configOrder := make(map[string]int)
+	keyOrder := make(map[string][]int)
 	for i, edge := range configEdges {
-		configOrder[edgeKey(edge)] = i
+		keyOrder[edgeKey(edge)] = append(keyOrder[edgeKey(edge)], i)
 	}
 
 	sort.SliceStable(edges, func(i, j int) bool {
-		keyI := edgeKey(edges[i])
-		keyJ := edgeKey(edges[j])
-		orderI, okI := configOrder[keyI]
-		orderJ, okJ := configOrder[keyJ]
+		return edges[i].ID.ValueString() < edges[j].ID.ValueString()
+	})
+
+	order := make([]int, len(edges))
+	claimed := make(map[int]bool)
+	for i, edge := range edges {
+		order[i] = -1
+		for _, o := range keyOrder[edgeKey(edge)] {
+			if !claimed[o] {
+				order[i] = o
+				claimed[o] = true
+				break
+			}
+		}
+	}
+
+	indexes := make([]int, len(edges))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		i, j := indexes[a], indexes[b]
+		okI, okJ := order[i] != -1, order[j] != -1
 
 		if okI && okJ {
-			return orderI < orderJ
+			return order[i] < order[j]
 		}
 		if okI {
 			return true
@@ -549,8 +763,36 @@ func sortEdgesByConfigOrder(edges []ResourcePipelineEdge, configEdges []Resource
 		if okJ {
 			return false
 		}
-		return keyI < keyJ
+		keyI, keyJ := edgeKey(edges[i]), edgeKey(edges[j])
+		if keyI != keyJ {
+			return keyI < keyJ
+		}
+		return edges[i].ID.ValueString() < edges[j].ID.ValueString()
 	})
+
+	sorted := make([]ResourcePipelineEdge, len(edges))
+	for i, idx := range indexes {
+		sorted[i] = edges[idx]
+	}
+	copy(edges, sorted)
+}
+
+// assignPipelineEdgeIDs copies the server-assigned edge ids from a Create or
+// Update response onto the planned edges. The response edges are sorted to
+// the planned order first, and an id is only taken when the slug pair at that
+// position matches, so a parallel edge never picks up its sibling's id.
+func assignPipelineEdgeIDs(edges []ResourcePipelineEdge, pipeline *monad.ModelsPipelineConfigV2) {
+	apiEdges := buildPipelineStateEdges(pipeline, edges)
+	for i := range edges {
+		edges[i].ID = types.StringNull()
+		if i >= len(apiEdges) {
+			continue
+		}
+		if apiEdges[i].FromNodeInstanceSlug.ValueString() == edges[i].FromNodeInstanceSlug.ValueString() &&
+			apiEdges[i].ToNodeInstanceSlug.ValueString() == edges[i].ToNodeInstanceSlug.ValueString() {
+			edges[i].ID = apiEdges[i].ID
+		}
+	}
 }
 
 // reconcilePipelineEnabled refreshes `enabled` from the API while preserving a
@@ -576,6 +818,15 @@ func reconcilePipelineNodes(prior, api []ResourcePipelineNode) []ResourcePipelin
 		return api
 	}
 
+	// A node enabled by default (null) matches an enabled API node. api is
+	// sorted to the prior order, so nodes are paired by position.
+	for i := range api {
+		if i < len(prior) && prior[i].ComponentID.Equal(api[i].ComponentID) &&
+			prior[i].Enabled.IsNull() && api[i].Enabled.ValueBool() {
+			api[i].Enabled = types.BoolNull()
+		}
+	}
+
 	priorSlugNull := make(map[string]bool, len(prior))
 	for _, n := range prior {
 		priorSlugNull[n.ComponentID.ValueString()] = n.Slug.IsNull()
@@ -596,9 +847,11 @@ func reconcilePipelineNodes(prior, api []ResourcePipelineNode) []ResourcePipelin
 }
 
 // reconcilePipelineEdges mirrors reconcilePipelineNodes for edges. Nullable
-// edge name/description that the practitioner omitted are masked so the
-// server-echoed values do not read as drift. Edges are matched positionally,
-// both lists having been sorted to the prior config order.
+// edge name/description and condition case_sensitive that the practitioner
+// omitted are masked so the server-echoed values do not read as drift. Edges
+// are matched positionally, both lists having been sorted to the prior config
+// order. The computed edge id is not part of the comparison; it is always
+// refreshed from the API.
 func reconcilePipelineEdges(prior, api []ResourcePipelineEdge) []ResourcePipelineEdge {
 	if len(prior) == 0 {
 		return api
@@ -616,10 +869,28 @@ func reconcilePipelineEdges(prior, api []ResourcePipelineEdge) []ResourcePipelin
 		if prior[i].Description.IsNull() {
 			masked[i].Description = types.StringNull()
 		}
+
+		if masked[i].Condition == nil || prior[i].Condition == nil {
+			continue
+		}
+		// Copied so masking leaves the API edges untouched.
+		condition := *masked[i].Condition
+		condition.Conditions = slices.Clone(condition.Conditions)
+		for j := range condition.Conditions {
+			if j < len(prior[i].Condition.Conditions) && prior[i].Condition.Conditions[j].Config.CaseSensitive.IsNull() {
+				condition.Conditions[j].Config.CaseSensitive = types.BoolNull()
+			}
+		}
+		masked[i].Condition = &condition
 	}
 
 	if reflect.DeepEqual(jsonNormalize(pipelineEdgesComparable(prior)), jsonNormalize(pipelineEdgesComparable(masked))) {
-		return prior
+		out := make([]ResourcePipelineEdge, len(prior))
+		copy(out, prior)
+		for i := range out {
+			out[i].ID = api[i].ID
+		}
+		return out
 	}
 	return api
 }
@@ -631,6 +902,7 @@ func pipelineNodesComparable(nodes []ResourcePipelineNode) []any {
 			"component_type": stringOrNil(n.ComponentType),
 			"component_id":   stringOrNil(n.ComponentID),
 			"slug":           stringOrNil(n.Slug),
+			"enabled":        boolOrNil(n.Enabled),
 		}
 	}
 	return out
@@ -639,13 +911,19 @@ func pipelineNodesComparable(nodes []ResourcePipelineNode) []any {
 func pipelineEdgesComparable(edges []ResourcePipelineEdge) []any {
 	out := make([]any, len(edges))
 	for i, e := range edges {
-		conditions := make([]any, len(e.Condition.Conditions))
-		for j, c := range e.Condition.Conditions {
+		condition := e.Condition
+		if condition == nil {
+			condition = &ResourcePipelineCondition{Operator: types.StringNull()}
+		}
+		conditions := make([]any, len(condition.Conditions))
+		for j, c := range condition.Conditions {
 			conditions[j] = map[string]any{
-				"type_id": stringOrNil(c.TypeID),
-				"key":     stringOrNil(c.Config.Key),
-				"rate":    stringOrNil(c.Config.Rate),
-				"value":   listOrNil(c.Config.Value),
+				"type_id":        stringOrNil(c.TypeID),
+				"key":            stringOrNil(c.Config.Key),
+				"rate":           stringOrNil(c.Config.Rate),
+				"threshold":      numberOrNil(c.Config.Threshold),
+				"value":          listOrNil(c.Config.Value),
+				"case_sensitive": boolOrNil(c.Config.CaseSensitive),
 			}
 		}
 		out[i] = map[string]any{
@@ -653,13 +931,50 @@ func pipelineEdgesComparable(edges []ResourcePipelineEdge) []any {
 			"description": stringOrNil(e.Description),
 			"from":        stringOrNil(e.FromNodeInstanceSlug),
 			"to":          stringOrNil(e.ToNodeInstanceSlug),
-			"operator":    stringOrNil(e.Condition.Operator),
+			"operator":    stringOrNil(condition.Operator),
 			"conditions":  conditions,
 		}
 	}
 	return out
 }
 
+// thresholdToAPI returns a condition threshold as the JSON number the API
+// expects: an int64 when the value is a whole number that fits, so it is not
+// sent as e.g. 1e+06, and a float64 otherwise.
+func thresholdToAPI(n types.Number) any {
+	f := n.ValueBigFloat()
+	if f.IsInt() {
+		if i, accuracy := f.Int64(); accuracy == big.Exact {
+			return i
+		}
+	}
+	v, _ := f.Float64()
+	return v
+}
+
+// thresholdFromAPI converts the threshold of an API condition config, decoded
+// as a float64 or json.Number, or sent as a numeric string, into a Number. Any
+// other value reads as null.
+func thresholdFromAPI(v any) types.Number {
+	switch t := v.(type) {
+	case float64:
+		return types.NumberValue(big.NewFloat(t))
+	case int64:
+		return types.NumberValue(new(big.Float).SetInt64(t))
+	case int:
+		return types.NumberValue(new(big.Float).SetInt64(int64(t)))
+	case json.Number:
+		if f, ok := new(big.Float).SetString(t.String()); ok {
+			return types.NumberValue(f)
+		}
+	case string:
+		if f, ok := new(big.Float).SetString(t); ok {
+			return types.NumberValue(f)
+		}
+	}
+	return types.NumberNull()
+}
+
 func stringOrNil(s types.String) any {
 	if s.IsNull() || s.IsUnknown() {
 		return nil
@@ -667,6 +982,20 @@ func stringOrNil(s types.String) any {
 	return s.ValueString()
 }
 
+func numberOrNil(n types.Number) any {
+	if n.IsNull() || n.IsUnknown() {
+		return nil
+	}
+	return thresholdToAPI(n)
+}
+
+func boolOrNil(b types.Bool) any {
+	if b.IsNull() || b.IsUnknown() {
+		return nil
+	}
+	return b.ValueBool()
+}
+
 func listOrNil(l types.List) any {
 	if l.IsNull() || l.IsUnknown() {
 		return nil
@@ -692,6 +1021,17 @@ func (r *ResourcePipeline) Update(
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireKnownPipeline(ctx, &resp.Diagnostics, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	enabled := true
 	if !data.Enabled.IsNull() {
 		enabled = data.Enabled.ValueBool()
@@ -705,41 +1045,247 @@ func (r *ResourcePipeline) Update(
 
 	request := monad.RoutesV2UpdatePipelineRequest{
 		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		Enabled:     enabled,
 		Nodes:       buildPipelineRequestNodes(data.Nodes),
 		Edges:       edges,
 	}
 
-	pipeline, monadResp, err := r.client.PipelinesAPI.
-		V2OrganizationIdPipelinesPipelineIdPatch(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		RoutesV2UpdatePipelineRequest(request).
-		Execute()
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	pipeline, monadResp, err := r.client.UpdatePipeline(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update pipeline, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update pipeline", err, monadResp)
 		return
 	}
 
-	// Preserve plan-known values (see Create); only the computed `id` is taken
-	// from the response.
+	// Preserve plan-known values (see Create); only the computed `id` and edge
+	// ids are taken from the response.
 	data.ID = types.StringValue(*pipeline.Id)
 
+	if settled, monadResp, err := awaitPipelineNodeIDs(ctx, r.client, organizationID, pipeline); err != nil {
+		addClientError(r.client, &resp.Diagnostics, "read updated pipeline", err, monadResp)
+	} else {
+		pipeline = settled
+	}
+	assignPipelineEdgeIDs(data.Edges, pipeline)
+
 	tflog.Trace(ctx, "updated a pipeline resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ValidateConfig rejects empty or duplicate node slugs, and edges whose
+// endpoints name no node slug, which would otherwise only fail on apply with
+// an opaque server error.
+func (r *ResourcePipeline) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var nodes, edges types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nodes"), &nodes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("edges"), &edges)...)
+	if resp.Diagnostics.HasError() || nodes.IsUnknown() || edges.IsUnknown() {
+		return
+	}
+
+	var nodeModels []ResourcePipelineNode
+	var edgeModels []ResourcePipelineEdge
+	resp.Diagnostics.Append(nodes.ElementsAs(ctx, &nodeModels, false)...)
+	resp.Diagnostics.Append(edges.ElementsAs(ctx, &edgeModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePipelineNodeSlugs(nodeModels)...)
+	resp.Diagnostics.Append(validatePipelineEdgeSlugs(nodeModels, edgeModels)...)
+}
+
+func (r *ResourcePipeline) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	applyDefaultDescription(ctx, r.client, req, resp)
+
+	if r.client == nil || !r.client.ValidateComponents || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var nodes []ResourcePipelineNode
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("nodes"), &nodes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	organizationID := plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(validatePipelineComponents(ctx, r.client, organizationID, nodes)...)
+}
+
+// validatePipelineNodeSlugs checks that each node slug that is set is
+// non-empty and unique within the pipeline, reporting every repeat at the
+// node that repeats it. Nodes without a slug are left to the server.
+func validatePipelineNodeSlugs(nodes []ResourcePipelineNode) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	first := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		if node.Slug.IsNull() || node.Slug.IsUnknown() {
+			continue
+		}
+		slugPath := path.Root("nodes").AtListIndex(i).AtName("slug")
+		slug := node.Slug.ValueString()
+		if slug == "" {
+			diags.AddAttributeError(
+				slugPath,
+				"Empty pipeline node slug",
+				"A node slug must not be empty. Omit slug to let the server generate one.",
+			)
+			continue
+		}
+		if j, ok := first[slug]; ok {
+			diags.AddAttributeError(
+				slugPath,
+				"Duplicate pipeline node slug",
+				fmt.Sprintf(
+					"The slug %q is already used by nodes[%d]. Node slugs must be unique within a pipeline.",
+					slug, j,
+				),
+			)
+			continue
+		}
+		first[slug] = i
+	}
+
+	return diags
+}
+
+// validatePipelineEdgeSlugs checks that each edge's from_node_instance_slug
+// and to_node_instance_slug name the slug of a node. Nothing is checked while
+// a node slug is unknown, and unknown edge endpoints are skipped.
+func validatePipelineEdgeSlugs(nodes []ResourcePipelineNode, edges []ResourcePipelineEdge) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	slugs := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if node.Slug.IsUnknown() {
+			return diags
+		}
+		if !node.Slug.IsNull() {
+			slugs[node.Slug.ValueString()] = true
+		}
+	}
+	known := slices.Sorted(maps.Keys(slugs))
+
+	for i, edge := range edges {
+		for _, endpoint := range []struct {
+			name string
+			slug types.String
+		}{
+			{"from_node_instance_slug", edge.FromNodeInstanceSlug},
+			{"to_node_instance_slug", edge.ToNodeInstanceSlug},
+		} {
+			if endpoint.slug.IsUnknown() || endpoint.slug.IsNull() || slugs[endpoint.slug.ValueString()] {
+				continue
+			}
+			diags.AddAttributeError(
+				path.Root("edges").AtListIndex(i).AtName(endpoint.name),
+				"Pipeline edge references an unknown node",
+				fmt.Sprintf(
+					"No node has the slug %q. Node slugs in this pipeline: %s. "+
+						"Nodes without a slug cannot be referenced by edges.",
+					endpoint.slug.ValueString(), quotedList(known),
+				),
+			)
+		}
+	}
+
+	return diags
+}
+
+// quotedList formats values as a comma-separated list of quoted strings, or
+// "none".
+func quotedList(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// validatePipelineComponents checks that each node's component_id names an
+// existing component of its component_type in organizationID, so a wrong id
+// or type fails at plan time instead of with an opaque server error on apply.
+// Nodes whose id or type is not yet known, or whose type has no lookup
+// endpoint, are skipped. Lookup failures other than a 404 only warn: the
+// server still validates.
+func validatePipelineComponents(ctx context.Context, c *client.Client, organizationID string, nodes []ResourcePipelineNode) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, node := range nodes {
+		if node.ComponentID.IsUnknown() || node.ComponentID.IsNull() ||
+			node.ComponentType.IsUnknown() || node.ComponentType.IsNull() {
+			continue
+		}
+
+		componentType := node.ComponentType.ValueString()
+		componentID := node.ComponentID.ValueString()
+
+		var monadResp *http.Response
+		var err error
+		switch componentType {
+		case "input":
+			_, monadResp, err = c.OrganizationInputsAPI.
+				V1OrganizationIdInputsInputIdGet(ctx, organizationID, componentID).
+				Execute()
+		case "output":
+			_, monadResp, err = c.OrganizationOutputsAPI.
+				V1OrganizationIdOutputsOutputIdGet(ctx, organizationID, componentID).
+				Execute()
+		case "transform":
+			_, monadResp, err = c.OrganizationTransformsAPI.
+				V1OrganizationIdTransformsTransformIdGet(ctx, componentID, organizationID).
+				Execute()
+		case "enrichment":
+			_, monadResp, err = c.OrganizationEnrichmentsAPI.
+				V3OrganizationIdEnrichmentsEnrichmentIdGet(ctx, organizationID, componentID).
+				Execute()
+		default:
+			continue
+		}
+		if err == nil {
+			continue
+		}
+
+		idPath := path.Root("nodes").AtListIndex(i).AtName("component_id")
+		if monadResp != nil && monadResp.StatusCode == http.StatusNotFound {
+			diags.AddAttributeError(
+				idPath,
+				"Pipeline node references an unknown component",
+				fmt.Sprintf(
+					"No %s with id %q exists in the organization. Check that component_id is correct "+
+						"and that component_type (%q) matches the kind of component it references.",
+					componentType, componentID, componentType,
+				),
+			)
+			continue
+		}
+		diags.AddAttributeWarning(
+			idPath,
+			"Pipeline component validation skipped",
+			fmt.Sprintf(
+				"Could not look up %s %q: %s. Response: %s",
+				componentType, componentID, err, getResponseBody(c, monadResp),
+			),
+		)
+	}
+
+	return diags
+}
+
 func (r *ResourcePipeline) Delete(
 	ctx context.Context,
 	req resource.DeleteRequest,
@@ -752,20 +1298,15 @@ func (r *ResourcePipeline) Delete(
 		return
 	}
 
-	_, monadResp, err := r.client.PipelinesAPI.V2OrganizationIdPipelinesPipelineIdDelete(
-		ctx,
-		r.client.OrganizationID,
-		data.ID.ValueString(),
-	).Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monadResp, err := r.client.DeletePipeline(ctx, resolveOrganizationID(r.client, data.OrganizationID), data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete pipeline, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete pipeline", err, monadResp)
 		return
 	}
 }
@@ -775,5 +1316,29 @@ func (r *ResourcePipeline) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	organizationID, pipelineID, err := parsePipelineImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pipelineID)...)
+	if organizationID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	}
+}
+
+// parsePipelineImportID splits a pipeline import id. A plain `pipeline_id`
+// returns an empty organization, meaning the provider's organization; the
+// `org_id/pipeline_id` form imports a pipeline from another organization.
+func parsePipelineImportID(id string) (organizationID, pipelineID string, err error) {
+	parts := strings.Split(id, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return "", parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("expected an import id of the form \"pipeline_id\" or \"org_id/pipeline_id\", got %q", id)
+	}
 }
@@ -0,0 +1,780 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	monad "github.com/monad-inc/sdk/go"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+// newTestClient returns a client pointed at a TLS test server running handler,
+// for organization "org".
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	return client.NewMonadAPIClient(server.URL, "token", "org", true)
+}
+
+func TestValidatePipelineComponents(t *testing.T) {
+	// Only output out-1 exists.
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/org/outputs/out-1" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "out-1"})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	t.Run("matching type and id", func(t *testing.T) {
+		diags := validatePipelineComponents(context.Background(), c, "org", []ResourcePipelineNode{{
+			ComponentType: types.StringValue("output"),
+			ComponentID:   types.StringValue("out-1"),
+		}})
+		if diags.HasError() {
+			t.Errorf("unexpected diagnostics: %s", diags)
+		}
+	})
+
+	t.Run("mismatched type", func(t *testing.T) {
+		diags := validatePipelineComponents(context.Background(), c, "org", []ResourcePipelineNode{
+			{
+				ComponentType: types.StringValue("output"),
+				ComponentID:   types.StringValue("out-1"),
+			},
+			{
+				// out-1 is an output, not an input.
+				ComponentType: types.StringValue("input"),
+				ComponentID:   types.StringValue("out-1"),
+			},
+		})
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("expected one error, got %s", diags)
+		}
+		d, ok := diags.Errors()[0].(interface{ Path() path.Path })
+		if !ok {
+			t.Fatalf("expected an attribute diagnostic, got %T", diags.Errors()[0])
+		}
+		if want := path.Root("nodes").AtListIndex(1).AtName("component_id"); !d.Path().Equal(want) {
+			t.Errorf("expected path %s, got %s", want, d.Path())
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), `No input with id "out-1"`) {
+			t.Errorf("unexpected detail: %s", diags.Errors()[0].Detail())
+		}
+	})
+
+	t.Run("unknown id is skipped", func(t *testing.T) {
+		diags := validatePipelineComponents(context.Background(), c, "org", []ResourcePipelineNode{{
+			ComponentType: types.StringValue("input"),
+			ComponentID:   types.StringUnknown(),
+		}})
+		if diags.HasError() {
+			t.Errorf("unexpected diagnostics: %s", diags)
+		}
+	})
+}
+
+func TestResourcePipelineImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &ResourcePipeline{}
+	s := resourceSchema(t, r)
+
+	cases := []struct {
+		name    string
+		id      string
+		wantID  string
+		wantOrg string
+		wantErr bool
+	}{
+		{name: "plain id", id: "pipe-1", wantID: "pipe-1"},
+		{name: "org and id", id: "org-2/pipe-1", wantID: "pipe-1", wantOrg: "org-2"},
+		{name: "empty org", id: "/pipe-1", wantErr: true},
+		{name: "empty id", id: "org-2/", wantErr: true},
+		{name: "too many parts", id: "org-2/pipe-1/extra", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.id}, resp)
+
+			if tc.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+			}
+
+			var id, org types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("organization_id"), &org)...)
+			if id.ValueString() != tc.wantID {
+				t.Errorf("expected id %q, got %v", tc.wantID, id)
+			}
+			if tc.wantOrg == "" {
+				if !org.IsNull() {
+					t.Errorf("expected null organization_id, got %v", org)
+				}
+			} else if org.ValueString() != tc.wantOrg {
+				t.Errorf("expected organization_id %q, got %v", tc.wantOrg, org)
+			}
+		})
+	}
+}
+
+func TestResolveOrganizationID(t *testing.T) {
+	c := &client.Client{OrganizationID: "provider-org"}
+
+	if got := resolveOrganizationID(c, types.StringNull()); got != "provider-org" {
+		t.Errorf("null override: expected provider org, got %q", got)
+	}
+	if got := resolveOrganizationID(c, types.StringValue("org-2")); got != "org-2" {
+		t.Errorf("override: expected org-2, got %q", got)
+	}
+}
+
+func TestResourcePipelineCreateRetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourcePipeline{})
+
+	for _, tt := range []struct {
+		name               string
+		pipelineMaxRetries *int
+		wantAttempts       int
+		wantError          bool
+	}{
+		{name: "client default", wantAttempts: 2},
+		{name: "pipeline override disables retries", pipelineMaxRetries: new(int), wantAttempts: 1, wantError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			var idempotencyKeys []string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v2/org/pipelines" {
+					http.NotFound(w, r)
+					return
+				}
+				attempts++
+				idempotencyKeys = append(idempotencyKeys, r.Header.Get("Idempotency-Key"))
+				if attempts == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": "pipe-1"}`))
+			})
+			c.PipelineMaxRetries = tt.pipelineMaxRetries
+			r := &ResourcePipeline{client: c}
+
+			value := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "security"),
+			})
+			req := resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: value},
+				Plan:   tfsdk.Plan{Schema: s, Raw: value},
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+
+			r.Create(ctx, req, resp)
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Fatalf("expected error %t, got %s", tt.wantError, resp.Diagnostics)
+			}
+			if tt.wantError {
+				return
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != "pipe-1" {
+				t.Errorf("expected id pipe-1, got %s", id)
+			}
+			if idempotencyKeys[0] == "" || idempotencyKeys[0] != idempotencyKeys[1] {
+				t.Errorf("expected the retry to reuse the Idempotency-Key, got %q", idempotencyKeys)
+			}
+		})
+	}
+}
+
+func TestPipelineConditionCaseSensitiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name          string
+		caseSensitive types.Bool
+	}{
+		{name: "true", caseSensitive: types.BoolValue(true)},
+		{name: "false", caseSensitive: types.BoolValue(false)},
+		{name: "omitted", caseSensitive: types.BoolNull()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			edges := []ResourcePipelineEdge{{
+				FromNodeInstanceSlug: types.StringValue("a"),
+				ToNodeInstanceSlug:   types.StringValue("b"),
+				Condition: &ResourcePipelineCondition{
+					Operator: types.StringValue("and"),
+					Conditions: []ResourcePipelineConditionCondition{{
+						TypeID: types.StringValue("key_has_value"),
+						Config: ResourcePipelineConditionConditionConfig{
+							Key:           types.StringValue("severity"),
+							Value:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("High")}),
+							Rate:          types.StringNull(),
+							CaseSensitive: tt.caseSensitive,
+						},
+					}},
+				},
+			}}
+
+			req, err := buildPipelineRequestEdges(ctx, edges)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := req[0].Conditions.Conditions[0].Config
+			got, ok := config["case_sensitive"]
+			if tt.caseSensitive.IsNull() {
+				if ok {
+					t.Fatalf("expected case_sensitive to be omitted, got %v", got)
+				}
+			} else if got != tt.caseSensitive.ValueBool() {
+				t.Fatalf("expected case_sensitive %t, got %v", tt.caseSensitive.ValueBool(), got)
+			}
+
+			// The API echoes the config back as decoded JSON.
+			raw, err := json.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var echoed map[string]any
+			if err := json.Unmarshal(raw, &echoed); err != nil {
+				t.Fatal(err)
+			}
+			state := buildPipelineStateEdges(&monad.ModelsPipelineConfigV2{
+				Edges: []monad.ModelsPipelineEdge{{
+					Conditions: &monad.ModelsPipelineEdgeConditions{
+						Operator:   req[0].Conditions.Operator,
+						Conditions: []monad.ModelsPipelineEdgeCondition{{TypeId: req[0].Conditions.Conditions[0].TypeId, Config: echoed}},
+					},
+				}},
+			}, nil)
+			if got := state[0].Condition.Conditions[0].Config.CaseSensitive; !got.Equal(tt.caseSensitive) {
+				t.Errorf("expected case_sensitive %s after round-trip, got %s", tt.caseSensitive, got)
+			}
+		})
+	}
+}
+
+func TestPipelineConditionRateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name string
+		rate types.String
+	}{
+		{name: "set", rate: types.StringValue("1s")},
+		{name: "omitted", rate: types.StringNull()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			edges := []ResourcePipelineEdge{{
+				FromNodeInstanceSlug: types.StringValue("a"),
+				ToNodeInstanceSlug:   types.StringValue("b"),
+				Condition: &ResourcePipelineCondition{
+					Operator: types.StringValue("and"),
+					Conditions: []ResourcePipelineConditionCondition{{
+						TypeID: types.StringValue("sample"),
+						Config: ResourcePipelineConditionConditionConfig{
+							Key:           types.StringNull(),
+							Value:         types.ListNull(types.StringType),
+							Rate:          tt.rate,
+							CaseSensitive: types.BoolNull(),
+						},
+					}},
+				},
+			}}
+
+			req, err := buildPipelineRequestEdges(ctx, edges)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := req[0].Conditions.Conditions[0].Config
+			if got, ok := config["rate"]; tt.rate.IsNull() && ok {
+				t.Fatalf("expected rate to be omitted, got %q", got)
+			} else if !tt.rate.IsNull() && got != tt.rate.ValueString() {
+				t.Fatalf("expected rate %s, got %v", tt.rate, got)
+			}
+
+			state := buildPipelineStateEdges(&monad.ModelsPipelineConfigV2{
+				Edges: []monad.ModelsPipelineEdge{{Conditions: req[0].Conditions}},
+			}, nil)
+			if got := state[0].Condition.Conditions[0].Config.Rate; !got.Equal(tt.rate) {
+				t.Errorf("expected rate %s after round-trip, got %s", tt.rate, got)
+			}
+		})
+	}
+}
+
+func TestPipelineEdgeWithoutConditions(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourcePipeline{})
+
+	edges := buildPipelineStateEdges(&monad.ModelsPipelineConfigV2{
+		Edges: []monad.ModelsPipelineEdge{
+			{FromNodeInstanceId: monad.PtrString("a"), ToNodeInstanceId: monad.PtrString("b")},
+			{FromNodeInstanceId: monad.PtrString("b"), ToNodeInstanceId: monad.PtrString("c"), Conditions: &monad.ModelsPipelineEdgeConditions{}},
+		},
+	}, nil)
+	for i, edge := range edges {
+		if edge.Condition != nil {
+			t.Errorf("edge %d: expected no condition, got %+v", i, edge.Condition)
+		}
+	}
+
+	state := tfsdk.State{Schema: s, Raw: schemaObjectValue(t, s, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "security"),
+	})}
+	if diags := state.SetAttribute(ctx, path.Root("edges"), edges); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+
+	if got := reconcilePipelineEdges(edges, edges); len(got) != len(edges) {
+		t.Fatalf("expected %d edges after reconcile, got %d", len(edges), len(got))
+	}
+
+	req, err := buildPipelineRequestEdges(ctx, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, edge := range req {
+		if edge.Conditions != nil {
+			t.Errorf("edge %d: expected conditions to be omitted, got %+v", i, edge.Conditions)
+		}
+	}
+}
+
+func TestAssignPipelineEdgeIDs(t *testing.T) {
+	pipeline := &monad.ModelsPipelineConfigV2{
+		Nodes: []monad.ModelsPipelineNode{
+			{Id: monad.PtrString("n1"), Slug: monad.PtrString("a")},
+			{Id: monad.PtrString("n2"), Slug: monad.PtrString("b")},
+		},
+		Edges: []monad.ModelsPipelineEdge{
+			{Id: monad.PtrString("e1"), FromNodeInstanceId: monad.PtrString("n1"), ToNodeInstanceId: monad.PtrString("n2")},
+		},
+	}
+	edges := []ResourcePipelineEdge{
+		{ID: types.StringUnknown(), FromNodeInstanceSlug: types.StringValue("a"), ToNodeInstanceSlug: types.StringValue("b")},
+		{ID: types.StringUnknown(), FromNodeInstanceSlug: types.StringValue("b"), ToNodeInstanceSlug: types.StringValue("a")},
+	}
+
+	assignPipelineEdgeIDs(edges, pipeline)
+
+	want := []types.String{types.StringValue("e1"), types.StringNull()}
+	for i, edge := range edges {
+		if !edge.ID.Equal(want[i]) {
+			t.Errorf("edge %d: expected id %s, got %s", i, want[i], edge.ID)
+		}
+	}
+}
+
+func TestAssignPipelineEdgeIDsInsertedEdge(t *testing.T) {
+	// An edge is inserted at index 0 of a pipeline whose a->b and b->c edges
+	// already exist. Each edge must get its own id back, not the id of the
+	// edge that used to sit at its index.
+	edge := func(id, from, to string) monad.ModelsPipelineEdge {
+		return monad.ModelsPipelineEdge{Id: monad.PtrString(id), FromNodeInstanceId: monad.PtrString(from), ToNodeInstanceId: monad.PtrString(to)}
+	}
+	pipeline := &monad.ModelsPipelineConfigV2{
+		Nodes: []monad.ModelsPipelineNode{
+			{Id: monad.PtrString("n1"), Slug: monad.PtrString("a")},
+			{Id: monad.PtrString("n2"), Slug: monad.PtrString("b")},
+			{Id: monad.PtrString("n3"), Slug: monad.PtrString("c")},
+		},
+		Edges: []monad.ModelsPipelineEdge{
+			edge("e1", "n1", "n2"),
+			edge("e2", "n2", "n3"),
+			edge("e3", "n3", "n1"),
+		},
+	}
+	edges := []ResourcePipelineEdge{
+		{ID: types.StringUnknown(), FromNodeInstanceSlug: types.StringValue("c"), ToNodeInstanceSlug: types.StringValue("a")},
+		{ID: types.StringUnknown(), FromNodeInstanceSlug: types.StringValue("a"), ToNodeInstanceSlug: types.StringValue("b")},
+		{ID: types.StringUnknown(), FromNodeInstanceSlug: types.StringValue("b"), ToNodeInstanceSlug: types.StringValue("c")},
+	}
+
+	assignPipelineEdgeIDs(edges, pipeline)
+
+	for i, want := range []string{"e3", "e1", "e2"} {
+		if !edges[i].ID.Equal(types.StringValue(want)) {
+			t.Errorf("edge %d: expected id %s, got %s", i, want, edges[i].ID)
+		}
+	}
+
+	// The next read keeps the config order even if state still holds the ids
+	// by their old index.
+	prior := []ResourcePipelineEdge{
+		{ID: types.StringValue("e1"), FromNodeInstanceSlug: types.StringValue("c"), ToNodeInstanceSlug: types.StringValue("a")},
+		{ID: types.StringValue("e2"), FromNodeInstanceSlug: types.StringValue("a"), ToNodeInstanceSlug: types.StringValue("b")},
+		{ID: types.StringNull(), FromNodeInstanceSlug: types.StringValue("b"), ToNodeInstanceSlug: types.StringValue("c")},
+	}
+	read := buildPipelineStateEdges(pipeline, prior)
+	for i, want := range []string{"e3", "e1", "e2"} {
+		if !read[i].ID.Equal(types.StringValue(want)) {
+			t.Errorf("read edge %d: expected id %s, got %s", i, want, read[i].ID)
+		}
+	}
+}
+
+func TestResourcePipelineWithoutDescription(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourcePipeline{})
+
+	for _, tt := range []struct {
+		name string
+		body string
+	}{
+		{name: "empty description", body: `{"id": "pipe-1", "name": "security", "description": ""}`},
+		{name: "absent description", body: `{"id": "pipe-1", "name": "security"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ResourcePipeline{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			})}
+			value := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "security"),
+			})
+
+			createResp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+			r.Create(ctx, resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: value},
+				Plan:   tfsdk.Plan{Schema: s, Raw: value},
+			}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("create: unexpected diagnostics: %s", createResp.Diagnostics)
+			}
+
+			readResp := &resource.ReadResponse{State: createResp.State}
+			r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("read: unexpected diagnostics: %s", readResp.Diagnostics)
+			}
+
+			for step, state := range map[string]tfsdk.State{"create": createResp.State, "read": readResp.State} {
+				var description types.String
+				if diags := state.GetAttribute(ctx, path.Root("description"), &description); diags.HasError() {
+					t.Fatal(diags)
+				}
+				if !description.IsNull() {
+					t.Errorf("%s: expected a null description, got %s", step, description)
+				}
+			}
+		})
+	}
+}
+
+func TestResourcePipelineCreateAwaitsNodeIDs(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourcePipeline{})
+
+	pending := `{"id": "pipe-1", "nodes": [{"component_id": "in-1"}, {"component_id": "out-1"}],
+		"edges": [{"id": "edge-1", "from_node_instance_id": "n-1", "to_node_instance_id": "n-2"}]}`
+	settled := `{"id": "pipe-1", "nodes": [{"id": "n-1", "slug": "src", "component_id": "in-1"}, {"id": "n-2", "slug": "dst", "component_id": "out-1"}],
+		"edges": [{"id": "edge-1", "from_node_instance_id": "n-1", "to_node_instance_id": "n-2"}]}`
+
+	var gets int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/org/pipelines":
+			_, _ = w.Write([]byte(pending))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/org/pipelines/pipe-1":
+			gets++
+			if gets == 1 {
+				_, _ = w.Write([]byte(pending))
+				return
+			}
+			_, _ = w.Write([]byte(settled))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c.RetryWaitMin = time.Millisecond
+	r := &ResourcePipeline{client: c}
+
+	plan := tfsdk.Plan{Schema: s, Raw: nullSchemaObjectValue(s)}
+	if diags := plan.Set(ctx, &ResourcePipelineModel{
+		ID:   types.StringUnknown(),
+		Name: types.StringValue("security"),
+		Nodes: []ResourcePipelineNode{
+			{ComponentType: types.StringValue("input"), ComponentID: types.StringValue("in-1"), Slug: types.StringValue("src")},
+			{ComponentType: types.StringValue("output"), ComponentID: types.StringValue("out-1"), Slug: types.StringValue("dst")},
+		},
+		Edges: []ResourcePipelineEdge{{
+			ID:                   types.StringUnknown(),
+			FromNodeInstanceSlug: types.StringValue("src"),
+			ToNodeInstanceSlug:   types.StringValue("dst"),
+		}},
+		Enabled:        types.BoolNull(),
+		OrganizationID: types.StringUnknown(),
+	}); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+	r.Create(ctx, resource.CreateRequest{Config: tfsdk.Config{Schema: s, Raw: plan.Raw}, Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics)
+	}
+
+	if gets != 2 {
+		t.Errorf("expected the pipeline to be re-read until its nodes had ids, got %d reads", gets)
+	}
+	var edgeID types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("edges").AtListIndex(0).AtName("id"), &edgeID)...)
+	if edgeID.ValueString() != "edge-1" {
+		t.Errorf("expected edge id edge-1, got %s", edgeID)
+	}
+}
+
+func TestResourcePipelineValidateEdgeSlugs(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourcePipeline{})
+
+	node := func(slug types.String) ResourcePipelineNode {
+		return ResourcePipelineNode{ComponentType: types.StringValue("input"), ComponentID: types.StringValue("in-1"), Slug: slug}
+	}
+	edge := func(from, to string) ResourcePipelineEdge {
+		return ResourcePipelineEdge{
+			ID:                   types.StringNull(),
+			FromNodeInstanceSlug: types.StringValue(from),
+			ToNodeInstanceSlug:   types.StringValue(to),
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		nodes     []ResourcePipelineNode
+		edges     []ResourcePipelineEdge
+		wantPaths []path.Path
+	}{
+		{
+			name:  "all endpoints exist",
+			nodes: []ResourcePipelineNode{node(types.StringValue("src")), node(types.StringValue("dst"))},
+			edges: []ResourcePipelineEdge{edge("src", "dst")},
+		},
+		{
+			name:  "typo in an endpoint",
+			nodes: []ResourcePipelineNode{node(types.StringValue("src")), node(types.StringValue("dst"))},
+			edges: []ResourcePipelineEdge{edge("src", "dst"), edge("src", "dts")},
+			wantPaths: []path.Path{
+				path.Root("edges").AtListIndex(1).AtName("to_node_instance_slug"),
+			},
+		},
+		{
+			name:  "both endpoints unknown to the pipeline",
+			nodes: []ResourcePipelineNode{node(types.StringNull())},
+			edges: []ResourcePipelineEdge{edge("a", "b")},
+			wantPaths: []path.Path{
+				path.Root("edges").AtListIndex(0).AtName("from_node_instance_slug"),
+				path.Root("edges").AtListIndex(0).AtName("to_node_instance_slug"),
+			},
+		},
+		{
+			name:  "node slug not known yet",
+			nodes: []ResourcePipelineNode{node(types.StringValue("src")), node(types.StringUnknown())},
+			edges: []ResourcePipelineEdge{edge("src", "dst")},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Plan{Schema: s, Raw: nullSchemaObjectValue(s)}
+			if diags := config.Set(ctx, &ResourcePipelineModel{
+				ID:             types.StringNull(),
+				Name:           types.StringValue("security"),
+				Description:    types.StringNull(),
+				Nodes:          tt.nodes,
+				Edges:          tt.edges,
+				Enabled:        types.BoolNull(),
+				OrganizationID: types.StringNull(),
+			}); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			resp := &resource.ValidateConfigResponse{}
+			(&ResourcePipeline{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: s, Raw: config.Raw},
+			}, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != len(tt.wantPaths) {
+				t.Fatalf("expected %d errors, got %s", len(tt.wantPaths), resp.Diagnostics)
+			}
+			for i, want := range tt.wantPaths {
+				d, ok := errs[i].(diag.DiagnosticWithPath)
+				if !ok || !d.Path().Equal(want) {
+					t.Errorf("expected an error at %s, got %v", want, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePipelineNodeSlugs(t *testing.T) {
+	node := func(slug types.String) ResourcePipelineNode {
+		return ResourcePipelineNode{ComponentType: types.StringValue("input"), ComponentID: types.StringValue("in-1"), Slug: slug}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		nodes     []ResourcePipelineNode
+		wantPaths []path.Path
+	}{
+		{
+			name:  "two nodes without slugs",
+			nodes: []ResourcePipelineNode{node(types.StringNull()), node(types.StringNull())},
+		},
+		{
+			name:  "unique slugs",
+			nodes: []ResourcePipelineNode{node(types.StringValue("a")), node(types.StringValue("b")), node(types.StringUnknown())},
+		},
+		{
+			name:      "duplicate slug",
+			nodes:     []ResourcePipelineNode{node(types.StringValue("a")), node(types.StringValue("b")), node(types.StringValue("a"))},
+			wantPaths: []path.Path{path.Root("nodes").AtListIndex(2).AtName("slug")},
+		},
+		{
+			name:      "empty slug",
+			nodes:     []ResourcePipelineNode{node(types.StringValue(""))},
+			wantPaths: []path.Path{path.Root("nodes").AtListIndex(0).AtName("slug")},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validatePipelineNodeSlugs(tt.nodes)
+			if len(diags.Errors()) != len(tt.wantPaths) {
+				t.Fatalf("expected %d errors, got %s", len(tt.wantPaths), diags)
+			}
+			for i, want := range tt.wantPaths {
+				d, ok := diags.Errors()[i].(diag.DiagnosticWithPath)
+				if !ok || !d.Path().Equal(want) {
+					t.Errorf("expected an error at %s, got %v", want, diags.Errors()[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPipelineConditionThresholdRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name      string
+		threshold types.Number
+		wantJSON  string
+	}{
+		{name: "whole number", threshold: types.NumberValue(big.NewFloat(1000000)), wantJSON: `"threshold":1000000`},
+		{name: "fraction", threshold: types.NumberValue(big.NewFloat(0.75)), wantJSON: `"threshold":0.75`},
+		{name: "omitted", threshold: types.NumberNull()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			edges := []ResourcePipelineEdge{{
+				FromNodeInstanceSlug: types.StringValue("a"),
+				ToNodeInstanceSlug:   types.StringValue("b"),
+				Condition: &ResourcePipelineCondition{
+					Operator: types.StringValue("and"),
+					Conditions: []ResourcePipelineConditionCondition{{
+						TypeID: types.StringValue("sample"),
+						Config: ResourcePipelineConditionConditionConfig{
+							Key:       types.StringValue("latency_ms"),
+							Value:     types.ListNull(types.StringType),
+							Rate:      types.StringValue("1s"),
+							Threshold: tt.threshold,
+						},
+					}},
+				},
+			}}
+
+			req, err := buildPipelineRequestEdges(ctx, edges)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := req[0].Conditions.Conditions[0].Config
+			raw, err := json.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.threshold.IsNull() {
+				if _, ok := config["threshold"]; ok {
+					t.Fatalf("expected threshold to be omitted, got %s", raw)
+				}
+			} else if !strings.Contains(string(raw), tt.wantJSON) {
+				t.Fatalf("expected %s in the request, got %s", tt.wantJSON, raw)
+			}
+			if config["rate"] != "1s" {
+				t.Errorf("expected rate to be sent unchanged next to threshold, got %v", config["rate"])
+			}
+
+			// The API echoes the config back as decoded JSON.
+			var echoed map[string]any
+			if err := json.Unmarshal(raw, &echoed); err != nil {
+				t.Fatal(err)
+			}
+			state := buildPipelineStateEdges(&monad.ModelsPipelineConfigV2{
+				Edges: []monad.ModelsPipelineEdge{{
+					Conditions: &monad.ModelsPipelineEdgeConditions{
+						Operator:   req[0].Conditions.Operator,
+						Conditions: []monad.ModelsPipelineEdgeCondition{{TypeId: req[0].Conditions.Conditions[0].TypeId, Config: echoed}},
+					},
+				}},
+			}, nil)
+			got := state[0].Condition.Conditions[0].Config
+			if !got.Threshold.Equal(tt.threshold) {
+				t.Errorf("expected threshold %s after round-trip, got %s", tt.threshold, got.Threshold)
+			}
+			if got.Rate.ValueString() != "1s" {
+				t.Errorf("expected rate 1s after round-trip, got %s", got.Rate)
+			}
+		})
+	}
+}
+
+func TestThresholdFromAPI(t *testing.T) {
+	for _, tt := range []struct {
+		in   any
+		want types.Number
+	}{
+		{in: float64(5), want: types.NumberValue(big.NewFloat(5))},
+		{in: json.Number("2.5"), want: types.NumberValue(big.NewFloat(2.5))},
+		{in: "10", want: types.NumberValue(big.NewFloat(10))},
+		{in: "1s", want: types.NumberNull()},
+		{in: nil, want: types.NumberNull()},
+	} {
+		if got := thresholdFromAPI(tt.in); !got.Equal(tt.want) {
+			t.Errorf("%#v: expected %s, got %s", tt.in, tt.want, got)
+		}
+	}
+}
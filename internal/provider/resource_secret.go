@@ -19,17 +19,20 @@ import (
 var _ resource.Resource = &ResourceSecret{}
 var _ resource.ResourceWithConfigure = &ResourceSecret{}
 var _ resource.ResourceWithImportState = &ResourceSecret{}
+var _ resource.ResourceWithModifyPlan = &ResourceSecret{}
 
 type ResourceSecret struct {
 	client *client.Client
 }
 
 type ResourceSecretModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Value       types.String `tfsdk:"value"`
-	ValueHash   types.String `tfsdk:"value_hash"`
+	ID             types.String      `tfsdk:"id"`
+	Name           types.String      `tfsdk:"name"`
+	Description    types.String      `tfsdk:"description"`
+	Value          types.String      `tfsdk:"value"`
+	ValueHash      types.String      `tfsdk:"value_hash"`
+	OrganizationID types.String      `tfsdk:"organization_id"`
+	Timeouts       *ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 func NewResourceSecret() resource.Resource {
@@ -91,6 +94,7 @@ func (r *ResourceSecret) Schema(
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the secret",
 				Optional:            true,
+				Computed:            true,
 			},
 			"value": schema.StringAttribute{
 				MarkdownDescription: "Value of the secret",
@@ -102,12 +106,17 @@ func (r *ResourceSecret) Schema(
 				MarkdownDescription: "HMAC hash of the secret value",
 				Computed:            true,
 			},
+			"organization_id": organizationIDAttribute("secret"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
-func (r *ResourceSecret) computeValueHash(ctx context.Context, value string) string {
-	return hmacSHA256Hex(ctx, secretsHashKey(r.client.OrganizationID), value)
+func (r *ResourceSecret) computeValueHash(ctx context.Context, organizationID, value string) string {
+	return hmacSHA256Hex(ctx, secretsHashKey(organizationID), value)
 }
 
 func (r *ResourceSecret) Create(
@@ -118,34 +127,35 @@ func (r *ResourceSecret) Create(
 	var data ResourceSecretModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	request := monad.RoutesV2CreateOrUpdateSecretRequest{
 		Name:        data.Name.ValueStringPointer(),
 		Description: data.Description.ValueStringPointer(),
 		Value:       data.Value.ValueStringPointer(),
 	}
 
-	secret, monadResp, err := r.client.SecretsAPI.
-		V2OrganizationIdSecretsPost(ctx, r.client.OrganizationID).
-		RoutesV2CreateOrUpdateSecretRequest(request).
-		Execute()
+	secret, monadResp, err := r.client.CreateSecret(ctx, organizationID, request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create secret, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create secret", err, monadResp)
 		return
 	}
 
 	data.ID = types.StringValue(*secret.Id)
-	data.ValueHash = types.StringValue(r.computeValueHash(ctx, data.Value.ValueString()))
+	data.OrganizationID = types.StringValue(organizationID)
+	data.ValueHash = types.StringValue(r.computeValueHash(ctx, organizationID, data.Value.ValueString()))
 
 	tflog.Trace(ctx, "created a secret resource")
 
@@ -164,28 +174,24 @@ func (r *ResourceSecret) Read(
 		return
 	}
 
-	secret, monadResp, err := r.client.SecretsAPI.
-		V2OrganizationIdSecretsSecretIdGet(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	secret, monadResp, err := r.client.GetSecret(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read secret, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addReadError(r.client, &resp.Diagnostics, "secret", data.ID.ValueString(), err, monadResp)
 		return
 	}
 
 	data.ID = types.StringValue(*secret.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 	data.Name = types.StringValue(*secret.Name)
-	data.Description = types.StringValue(*secret.Description)
+	data.Description = refreshDescription(data.Description, secret.Description)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -198,46 +204,76 @@ func (r *ResourceSecret) Update(
 	var data ResourceSecretModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// `value` is write-only, so it is only present in the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value"), &data.Value)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	request := monad.RoutesV2CreateOrUpdateSecretRequest{
 		Name:        data.Name.ValueStringPointer(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		Value:       data.Value.ValueStringPointer(),
 	}
 
-	secret, monadResp, err := r.client.SecretsAPI.
-		V2OrganizationIdSecretsSecretIdPatch(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		RoutesV2CreateOrUpdateSecretRequest(request).
-		Execute()
+	secret, monadResp, err := r.client.UpdateSecret(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update secret, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update secret", err, monadResp)
 		return
 	}
 
 	data.ID = types.StringValue(*secret.Id)
 	data.Name = types.StringValue(*secret.Name)
-	data.Description = types.StringValue(*secret.Description)
-	data.ValueHash = types.StringValue(r.computeValueHash(ctx, data.Value.ValueString()))
+	data.Description = refreshDescription(data.Description, secret.Description)
+	data.OrganizationID = types.StringValue(organizationID)
+	data.ValueHash = types.StringValue(r.computeValueHash(ctx, organizationID, data.Value.ValueString()))
 
 	tflog.Trace(ctx, "updated a secret resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+func (r *ResourceSecret) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	applyDefaultDescription(ctx, r.client, req, resp)
+	if r.client == nil {
+		return
+	}
+	r.modifyPlanForValue(ctx, plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics), req, resp)
+}
+
+// modifyPlanForValue plans `value_hash` from the configured write-only
+// `value`. Left to the framework it would be unknown on every update, so a
+// description-only change would also show `value_hash` as known after apply,
+// and a rotated value (null in state) would show no diff at all. With the
+// hash planned, the diff lists exactly the attributes that change, and only
+// `value` is redacted.
+func (r *ResourceSecret) modifyPlanForValue(ctx context.Context, organizationID string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var value types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value"), &value)...)
+	if resp.Diagnostics.HasError() || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	hash := r.computeValueHash(ctx, organizationID, value.ValueString())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value_hash"), types.StringValue(hash))...)
+}
+
 func (r *ResourceSecret) Delete(
 	ctx context.Context,
 	req resource.DeleteRequest,
@@ -250,22 +286,17 @@ func (r *ResourceSecret) Delete(
 		return
 	}
 
-	monadResp, err := r.client.SecretsAPI.
-		V2OrganizationIdSecretsSecretIdDelete(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).
-		Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	monadResp, err := r.client.DeleteSecret(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete secret, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete secret", err, monadResp)
 		return
 	}
 }
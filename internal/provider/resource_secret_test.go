@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
+)
+
+func TestResourceSecretPlanShowsReadableDiff(t *testing.T) {
+	ctx := context.Background()
+	r := &ResourceSecret{client: &client.Client{OrganizationID: "org"}}
+	s := resourceSchema(t, r)
+
+	for name, a := range s.Attributes {
+		if sensitive := a.(schema.StringAttribute).Sensitive; sensitive != (name == "value") {
+			t.Errorf("%s: expected only value to be sensitive, got Sensitive=%t", name, sensitive)
+		}
+	}
+
+	storedHash := r.computeValueHash(ctx, "org", "hunter2")
+	state := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "sec-1"),
+		"name":            tftypes.NewValue(tftypes.String, "splunk-token"),
+		"description":     tftypes.NewValue(tftypes.String, "HEC token"),
+		"value_hash":      tftypes.NewValue(tftypes.String, storedHash),
+		"organization_id": tftypes.NewValue(tftypes.String, "org"),
+	})
+
+	plan := func(description, value string) types.String {
+		config := schemaObjectValue(t, s, map[string]tftypes.Value{
+			"name":        tftypes.NewValue(tftypes.String, "splunk-token"),
+			"description": tftypes.NewValue(tftypes.String, description),
+			"value":       tftypes.NewValue(tftypes.String, value),
+		})
+		// As planned by the framework: computed attributes are unknown on update.
+		planned := schemaObjectValue(t, s, map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "sec-1"),
+			"name":            tftypes.NewValue(tftypes.String, "splunk-token"),
+			"description":     tftypes.NewValue(tftypes.String, description),
+			"value_hash":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"organization_id": tftypes.NewValue(tftypes.String, "org"),
+		})
+		req, resp := newModifyPlanRequest(s, config, planned, state)
+		r.ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics %s", resp.Diagnostics)
+		}
+		var hash types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("value_hash"), &hash)...)
+		return hash
+	}
+
+	// A description-only change leaves value_hash as it is, so description is
+	// the only attribute in the diff.
+	if hash := plan("Splunk HEC token", "hunter2"); hash.ValueString() != storedHash {
+		t.Errorf("description change: expected value_hash to stay %s, got %s", storedHash, hash)
+	}
+
+	// A rotated value shows as a value_hash change.
+	if hash := plan("HEC token", "hunter3"); hash.IsUnknown() || hash.ValueString() == storedHash {
+		t.Errorf("rotation: expected a new known value_hash, got %s", hash)
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,16 +24,21 @@ import (
 var _ resource.Resource = &ResourceTransform{}
 var _ resource.ResourceWithConfigure = &ResourceTransform{}
 var _ resource.ResourceWithImportState = &ResourceTransform{}
+var _ resource.ResourceWithModifyPlan = &ResourceTransform{}
+var _ resource.ResourceWithValidateConfig = &ResourceTransform{}
 
 type ResourceTransform struct {
 	client *client.Client
 }
 
 type ResourceTransformModel struct {
-	ID          types.String  `tfsdk:"id"`
-	Name        types.String  `tfsdk:"name"`
-	Description types.String  `tfsdk:"description"`
-	Config      types.Dynamic `tfsdk:"config"`
+	ID                 types.String      `tfsdk:"id"`
+	Name               types.String      `tfsdk:"name"`
+	Description        types.String      `tfsdk:"description"`
+	Config             types.Dynamic     `tfsdk:"config"`
+	ValidateWithSample types.Dynamic     `tfsdk:"validate_with_sample"`
+	OrganizationID     types.String      `tfsdk:"organization_id"`
+	Timeouts           *ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 func NewResourceTransform() resource.Resource {
@@ -45,6 +53,58 @@ func (r *ResourceTransform) Metadata(
 	resp.TypeName = req.ProviderTypeName + "_transform"
 }
 
+// ValidateConfig rejects a config or validate_with_sample that is not an
+// object, which would otherwise only fail on apply with a generic conversion
+// error.
+func (r *ResourceTransform) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	resp.Diagnostics.Append(validateTransformSample(ctx, req.Config)...)
+
+	var config types.Dynamic
+	diags := req.Config.GetAttribute(ctx, path.Root("config"), &config)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || config.IsNull() || config.IsUnknown() || config.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	if _, ok := objectElements(config.UnderlyingValue()); ok {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("config"),
+		"Invalid transform config",
+		fmt.Sprintf(
+			"config must be an object with an `operations` list, e.g. "+
+				"{ operations = [{ operation = \"...\", arguments = { ... } }] }, got %s. "+
+				"Omit `operations` for a passthrough transform.",
+			valueKind(config.UnderlyingValue()),
+		),
+	)
+}
+
+// validateTransformSample rejects a validate_with_sample that is not an
+// object, since the sandbox only runs transforms on records.
+func validateTransformSample(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var sample types.Dynamic
+	diags := config.GetAttribute(ctx, path.Root("validate_with_sample"), &sample)
+	if diags.HasError() || sample.IsNull() || sample.IsUnknown() || sample.IsUnderlyingValueUnknown() {
+		return diags
+	}
+
+	if _, ok := objectElements(sample.UnderlyingValue()); ok {
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("validate_with_sample"),
+		"Invalid sample record",
+		fmt.Sprintf("validate_with_sample must be an object, got %s.", valueKind(sample.UnderlyingValue())),
+	)
+	return diags
+}
+
 func (r *ResourceTransform) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -92,12 +152,28 @@ func (r *ResourceTransform) Schema(
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the transform",
 				Optional:            true,
+				Computed:            true,
 			},
+			"organization_id": organizationIDAttribute("transform"),
 			"config": schema.DynamicAttribute{
-				MarkdownDescription: "Transform configuration",
-				Required:            true,
+				MarkdownDescription: "Transform configuration: an object with an `operations` list. " +
+					"Omitting `operations`, or setting it to null or an empty list, creates a " +
+					"passthrough transform. Credentials can be referenced anywhere in the config " +
+					"as `{ \"$secret\" = \"<secret id>\" }`; the reference is sent as written and " +
+					"the secret must exist in the organization.",
+				Required: true,
+			},
+			"validate_with_sample": schema.DynamicAttribute{
+				MarkdownDescription: "Sample record, as an object, that the transform is run against in the " +
+					"Monad sandbox before every create and update. The apply fails, and nothing is " +
+					"saved, when the transform cannot process the record.",
+				Optional: true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -109,10 +185,20 @@ func (r *ResourceTransform) Create(
 	var data ResourceTransformModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// `description` may be planned from the provider's default_description.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("description"), &data.Description)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "create")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
 	transformConfig, err := parseTransformConfig(ctx, data.Config)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -121,28 +207,20 @@ func (r *ResourceTransform) Create(
 		)
 		return
 	}
+	applyTransformToSample(ctx, r.client, transformConfig, data.ValidateWithSample, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	request := monad.RoutesCreateTransformRequest{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueStringPointer(),
 		Config:      transformConfig,
 	}
 
-	transform, monadResp, err := r.client.OrganizationTransformsAPI.
-		V1OrganizationIdTransformsPost(
-			ctx,
-			r.client.OrganizationID,
-		).RoutesCreateTransformRequest(request).
-		Execute()
-
+	transform, monadResp, err := r.client.CreateTransform(ctx, organizationID, request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to create transform, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "create transform", err, monadResp)
 		return
 	}
 
@@ -154,6 +232,7 @@ func (r *ResourceTransform) Create(
 	// verbatim — rebuilding it from the API response yields a different cty
 	// type and trips "Provider produced inconsistent result after apply".
 	data.ID = types.StringValue(*transform.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 
 	tflog.Trace(ctx, "created a transform resource")
 
@@ -172,40 +251,34 @@ func (r *ResourceTransform) Read(
 		return
 	}
 
-	transform, monadResp, err := r.client.OrganizationTransformsAPI.
-		V1OrganizationIdTransformsTransformIdGet(
-			ctx,
-			data.ID.ValueString(),
-			r.client.OrganizationID,
-		).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to read transform, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "read")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	description := types.StringNull()
-	if transform.Description != nil && *transform.Description != "" {
-		description = types.StringValue(*transform.Description)
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	transform, monadResp, err := r.client.GetTransform(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		addReadError(r.client, &resp.Diagnostics, "transform", data.ID.ValueString(), err, monadResp)
+		return
 	}
 
 	data.ID = types.StringValue(*transform.Id)
+	data.OrganizationID = types.StringValue(organizationID)
 	data.Name = types.StringValue(*transform.Name)
-	data.Description = description
+	data.Description = refreshDescription(data.Description, transform.Description)
 
 	// Reconcile config for drift without cty-type churn: keep the prior
 	// state value (preserving the practitioner-authored representation, e.g. a
 	// jsondecode `operations` tuple) when the API-derived config is
 	// semantically equal, and only adopt the API value when it genuinely
 	// differs. On import prior state is null, so the API value populates.
-	apiConfig, err := transformConfigToMap(transform.Config)
+	// A prior config that cannot be converted restores no secret references;
+	// reconcileDynamic then adopts the API value.
+	priorConfig, _ := tfDynamicToMapAny(data.Config)
+	apiConfig, err := transformConfigToMap(transform.Config, priorConfig)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to convert transform config", err.Error())
 		return
@@ -221,8 +294,12 @@ func (r *ResourceTransform) Read(
 }
 
 // transformConfigToMap converts an API transform config into a plain map for
-// semantic drift comparison in Read.
-func transformConfigToMap(in *monad.ModelsTransformConfig) (map[string]any, error) {
+// semantic drift comparison in Read. Secret references in prior are put back
+// wherever the API returns a value in their place, since the API may echo the
+// resolved or redacted secret instead of the reference. The API omits empty
+// `arguments`, which are restored as `{}`, the shape every operation is
+// configured with.
+func transformConfigToMap(in *monad.ModelsTransformConfig, prior map[string]any) (map[string]any, error) {
 	if in == nil {
 		return nil, nil
 	}
@@ -236,6 +313,14 @@ func transformConfigToMap(in *monad.ModelsTransformConfig) (map[string]any, erro
 	if err := json.Unmarshal(jsonB, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal transform config: %w", err)
 	}
+	if operations, ok := config["operations"].([]any); ok {
+		for _, operation := range operations {
+			if operation, ok := operation.(map[string]any); ok && operation["arguments"] == nil {
+				operation["arguments"] = map[string]any{}
+			}
+		}
+	}
+	restoreSecretReferences(config, prior)
 
 	return config, nil
 }
@@ -251,6 +336,14 @@ func (r *ResourceTransform) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "update")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
 	transformConfig, err := parseTransformConfig(ctx, data.Config)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -260,39 +353,180 @@ func (r *ResourceTransform) Update(
 		return
 	}
 
+	applyTransformToSample(ctx, r.client, transformConfig, data.ValidateWithSample, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	request := monad.RoutesUpdateTransformRequest{
 		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueStringPointer(),
+		Description: updateDescription(data.Description),
 		Config:      transformConfig,
 	}
 
-	_, monadResp, err := r.client.OrganizationTransformsAPI.
-		V1OrganizationIdTransformsTransformIdPatch(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).RoutesUpdateTransformRequest(request).
-		Execute()
+	_, monadResp, err := r.client.UpdateTransform(ctx, organizationID, data.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to update transform, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "update transform", err, monadResp)
 		return
 	}
 
 	// Preserve plan-known values (see Create): `data` already holds
 	// id/name/description/config from the plan, so nothing is copied back from
 	// the API response.
+	data.OrganizationID = types.StringValue(organizationID)
+
 	tflog.Trace(ctx, "updated a transform resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// applyTransformToSample runs config against the sample record in the Monad
+// sandbox, so a transform that cannot process it fails the apply before it is
+// saved. A null sample skips the check.
+func applyTransformToSample(
+	ctx context.Context,
+	c *client.Client,
+	config *monad.RoutesTransformConfig,
+	sample types.Dynamic,
+	diags *diag.Diagnostics,
+) {
+	if sample.IsNull() || sample.IsUnknown() {
+		return
+	}
+
+	record, err := tfDynamicToMapAny(sample)
+	if err != nil {
+		diags.AddAttributeError(path.Root("validate_with_sample"), "Invalid sample record", err.Error())
+		return
+	}
+	request, err := applyTransformationRequest(config, record)
+	if err != nil {
+		diags.AddError("Failed to build the sample transformation", err.Error())
+		return
+	}
+
+	_, monadResp, err := c.ApplyTransformation(ctx, request)
+	if err == nil {
+		return
+	}
+	if monadResp != nil && monadResp.StatusCode >= 400 && monadResp.StatusCode < 500 {
+		diags.AddAttributeError(
+			path.Root("validate_with_sample"),
+			"Transform failed on the sample record",
+			fmt.Sprintf(
+				"The transform was not saved because it could not process validate_with_sample: %s. Response: %s",
+				err, getResponseBody(c, monadResp),
+			),
+		)
+		return
+	}
+	addClientError(c, diags, "run transform on the sample record", err, monadResp)
+}
+
+// applyTransformationRequest builds the sandbox request for config and record.
+// The sandbox takes the record as JSON bytes and the config in its stored
+// (models) shape, which has the same JSON encoding as the request shape.
+func applyTransformationRequest(config *monad.RoutesTransformConfig, record map[string]any) (monad.RoutesV2ApplyTransformationRequest, error) {
+	var request monad.RoutesV2ApplyTransformationRequest
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return request, fmt.Errorf("failed to marshal sample record: %w", err)
+	}
+	request.Record = make([]int32, len(recordJSON))
+	for i, b := range recordJSON {
+		request.Record[i] = int32(b)
+	}
+
+	if config != nil {
+		configJSON, err := json.Marshal(config)
+		if err != nil {
+			return request, fmt.Errorf("failed to marshal transform config: %w", err)
+		}
+		request.Config = &monad.ModelsTransformConfig{}
+		if err := json.Unmarshal(configJSON, request.Config); err != nil {
+			return request, fmt.Errorf("failed to convert transform config: %w", err)
+		}
+	}
+
+	return request, nil
+}
+
+func (r *ResourceTransform) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	applyDefaultDescription(ctx, r.client, req, resp)
+
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config types.Dynamic
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("config"), &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configMap, err := tfDynamicToMapAny(config)
+	if err != nil {
+		// Reported with more context by parseTransformConfig on apply.
+		return
+	}
+	refs, err := secretReferences(configMap)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Invalid secret reference", err.Error())
+		return
+	}
+	if len(refs) == 0 {
+		return
+	}
+	organizationID := plannedOrganizationID(ctx, r.client, req.Plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(validateSecretReferences(ctx, r.client, organizationID, refs)...)
+}
+
+// validateSecretReferences checks that each referenced secret exists in
+// organizationID, so a wrong id fails at plan time. refs maps the location of
+// each reference within config to the secret id.
+func validateSecretReferences(ctx context.Context, c *client.Client, organizationID string, refs map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	locations := make([]string, 0, len(refs))
+	for location := range refs {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	checked := make(map[string]bool)
+	for _, location := range locations {
+		id := refs[location]
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+
+		_, monadResp, err := c.GetSecret(ctx, organizationID, id)
+		if err == nil {
+			continue
+		}
+		if client.IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("config"),
+				"Transform config references an unknown secret",
+				fmt.Sprintf("%s references secret %q, which does not exist in the organization.", location, id),
+			)
+			continue
+		}
+		diags.AddAttributeWarning(
+			path.Root("config"),
+			"Secret reference validation skipped",
+			fmt.Sprintf("Could not look up secret %q: %s. Response: %s", id, err, getResponseBody(c, monadResp)),
+		)
+	}
+
+	return diags
+}
+
 func (r *ResourceTransform) Delete(
 	ctx context.Context,
 	req resource.DeleteRequest,
@@ -305,21 +539,17 @@ func (r *ResourceTransform) Delete(
 		return
 	}
 
-	_, monadResp, err := r.client.OrganizationTransformsAPI.
-		V1OrganizationIdTransformsTransformIdDelete(
-			ctx,
-			r.client.OrganizationID,
-			data.ID.ValueString(),
-		).Execute()
+	ctx, cancel := withTimeout(ctx, &resp.Diagnostics, data.Timeouts, "delete")
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := resolveOrganizationID(r.client, data.OrganizationID)
+
+	monadResp, err := r.client.DeleteTransform(ctx, organizationID, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf(
-				"Unable to delete transform, got error: %s. Response: %s",
-				err,
-				getResponseBody(monadResp),
-			),
-		)
+		addClientError(r.client, &resp.Diagnostics, "delete transform", err, monadResp)
 		return
 	}
 }
@@ -341,9 +571,16 @@ func parseTransformConfig(ctx context.Context, configDynamic types.Dynamic) (*mo
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert config to map: %w", err)
 	}
+	// Secret references are sent as written; the API resolves them.
+	if _, err := secretReferences(configMap); err != nil {
+		return nil, err
+	}
 
+	// A missing, null, or empty `operations` all describe a passthrough
+	// transform. They are sent identically, with no operations at all, since
+	// the API may reject an explicit empty list.
 	operationsInterface, exists := configMap["operations"]
-	if !exists {
+	if !exists || operationsInterface == nil {
 		return &monad.RoutesTransformConfig{}, nil
 	}
 
@@ -358,6 +595,9 @@ func parseTransformConfig(ctx context.Context, configDynamic types.Dynamic) (*mo
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse operations: %w", err)
 	}
+	if len(operations) == 0 {
+		return &monad.RoutesTransformConfig{}, nil
+	}
 
 	transformConfig := &monad.RoutesTransformConfig{
 		Operations: operations,
@@ -431,3 +671,102 @@ func parseOperations(_ context.Context, operationsDynamic types.Dynamic) ([]mona
 
 	return operations, nil
 }
+
+// secretReferenceKey marks a reference to a Monad secret inside transform
+// config: an object whose only key is "$secret", holding the secret id.
+const secretReferenceKey = "$secret"
+
+// secretReferences returns the secret references in config, keyed by their
+// location (e.g. `operations[0].arguments.api_key`). An object that uses the
+// "$secret" key any other way is an error.
+func secretReferences(config map[string]any) (map[string]string, error) {
+	refs := make(map[string]string)
+	if err := collectSecretReferences(config, "", refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+func collectSecretReferences(v any, location string, refs map[string]string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v[secretReferenceKey]; ok {
+			id, isString := ref.(string)
+			if !isString || id == "" || len(v) != 1 {
+				return fmt.Errorf(
+					"%s: a secret reference must be an object with only a non-empty %q string, e.g. { \"$secret\" = \"<secret id>\" }",
+					locationOrRoot(location), secretReferenceKey,
+				)
+			}
+			refs[locationOrRoot(location)] = id
+			return nil
+		}
+		for key, value := range v {
+			next := key
+			if location != "" {
+				next = location + "." + key
+			}
+			if err := collectSecretReferences(value, next, refs); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, value := range v {
+			if err := collectSecretReferences(value, fmt.Sprintf("%s[%d]", location, i), refs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func locationOrRoot(location string) string {
+	if location == "" {
+		return "config"
+	}
+	return location
+}
+
+// isSecretReference reports whether v is a well-formed secret reference.
+func isSecretReference(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return false
+	}
+	id, ok := m[secretReferenceKey].(string)
+	return ok && id != ""
+}
+
+// restoreSecretReferences replaces, in place, each value in api that sits
+// where prior holds a secret reference with that reference.
+func restoreSecretReferences(api, prior any) {
+	switch a := api.(type) {
+	case map[string]any:
+		p, ok := prior.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, value := range a {
+			if isSecretReference(p[key]) {
+				a[key] = p[key]
+				continue
+			}
+			restoreSecretReferences(value, p[key])
+		}
+	case []any:
+		p, ok := prior.([]any)
+		if !ok {
+			return
+		}
+		for i := range a {
+			if i >= len(p) {
+				return
+			}
+			if isSecretReference(p[i]) {
+				a[i] = p[i]
+				continue
+			}
+			restoreSecretReferences(a[i], p[i])
+		}
+	}
+}
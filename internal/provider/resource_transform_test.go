@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	monad "github.com/monad-inc/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransformConfigPassthrough(t *testing.T) {
+	cases := map[string]map[string]any{
+		"missing operations": {"label": "passthrough"},
+		"null operations":    {"operations": nil},
+		"empty operations":   {"operations": []any{}},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			dyn, err := AnyToDynamic(config)
+			require.NoError(t, err)
+
+			got, err := parseTransformConfig(context.Background(), dyn)
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Nil(t, got.Operations, "a passthrough transform must send no operations")
+		})
+	}
+}
+
+func TestTransformConfigSecretReference(t *testing.T) {
+	ctx := context.Background()
+	config := map[string]any{
+		"operations": []any{
+			map[string]any{
+				"operation": "enrich",
+				"arguments": map[string]any{
+					"field":   "ip",
+					"api_key": map[string]any{"$secret": "sec-1"},
+				},
+			},
+		},
+	}
+	dyn, err := AnyToDynamic(config)
+	require.NoError(t, err)
+
+	// The reference is sent as written.
+	got, err := parseTransformConfig(ctx, dyn)
+	require.NoError(t, err)
+	require.Len(t, got.Operations, 1)
+	arguments := *got.Operations[0].Arguments.MapmapOfStringAny
+	assert.Equal(t, map[string]any{"$secret": "sec-1"}, arguments["api_key"])
+
+	// The API echoes a redacted value in its place; Read restores the
+	// reference, so there is no drift.
+	apiConfig, err := transformConfigToMap(&monad.ModelsTransformConfig{
+		Operations: []monad.ModelsTransformOperation{{
+			Operation: monad.PtrString("enrich"),
+			Arguments: map[string]any{"field": "ip", "api_key": "********"},
+		}},
+	}, config)
+	require.NoError(t, err)
+	reconciled, err := reconcileDynamic(dyn, apiConfig)
+	require.NoError(t, err)
+	assert.True(t, reconciled.Equal(dyn), "a restored secret reference must not read as drift")
+
+	refs, err := secretReferences(config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"operations[0].arguments.api_key": "sec-1"}, refs)
+
+	for name, ref := range map[string]any{
+		"non-string id": map[string]any{"$secret": float64(1)},
+		"empty id":      map[string]any{"$secret": ""},
+		"extra keys":    map[string]any{"$secret": "sec-1", "name": "key"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := secretReferences(map[string]any{"operations": []any{map[string]any{"arguments": map[string]any{"api_key": ref}}}})
+			assert.ErrorContains(t, err, "operations[0].arguments.api_key")
+		})
+	}
+}
+
+func TestTransformConfigEmptyArguments(t *testing.T) {
+	ctx := context.Background()
+	config := map[string]any{
+		"operations": []any{
+			map[string]any{"operation": "flatten", "arguments": map[string]any{}},
+		},
+	}
+	dyn, err := AnyToDynamic(config)
+	require.NoError(t, err)
+
+	// The empty arguments are sent as an empty object, not null.
+	got, err := parseTransformConfig(ctx, dyn)
+	require.NoError(t, err)
+	require.Len(t, got.Operations, 1)
+	sent, err := json.Marshal(got.Operations[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"operation": "flatten", "arguments": {}}`, string(sent))
+
+	// The API omits them on Read. Whether refreshed against prior state or
+	// imported without one, they read back as the configured `{}`.
+	apiConfig, err := transformConfigToMap(&monad.ModelsTransformConfig{
+		Operations: []monad.ModelsTransformOperation{{Operation: monad.PtrString("flatten")}},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, config, apiConfig)
+
+	reconciled, err := reconcileDynamic(dyn, apiConfig)
+	require.NoError(t, err)
+	assert.True(t, reconciled.Equal(dyn), "empty arguments must not read as drift")
+
+	imported, err := reconcileDynamic(types.DynamicNull(), apiConfig)
+	require.NoError(t, err)
+	assert.True(t, imported.Equal(dyn), "expected %s, got %s", dyn, imported)
+
+	// A top-level empty object is kept as well.
+	empty, err := AnyToDynamic(map[string]any{})
+	require.NoError(t, err)
+	assert.False(t, empty.IsNull())
+	emptyMap, err := tfDynamicToMapAny(empty)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, emptyMap)
+}
+
+func TestValidateSecretReferences(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v2/org/secrets/sec-1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "sec-1", "name": "api-key"}`))
+	})
+
+	diags := validateSecretReferences(context.Background(), c, "org", map[string]string{
+		"operations[0].arguments.api_key": "sec-1",
+		"operations[1].arguments.token":   "missing",
+	})
+	require.Equal(t, 1, diags.ErrorsCount(), "%s", diags)
+	assert.Contains(t, diags.Errors()[0].Detail(), `operations[1].arguments.token references secret "missing"`)
+}
+
+func TestResourceTransformValidateConfigShape(t *testing.T) {
+	ctx := context.Background()
+	r := &ResourceTransform{}
+	s := resourceSchema(t, r)
+
+	for name, config := range map[string]tftypes.Value{
+		"object": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"operations": tftypes.Tuple{ElementTypes: []tftypes.Type{}},
+		}}, map[string]tftypes.Value{
+			"operations": tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{}}, []tftypes.Value{}),
+		}),
+		"list": tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String}}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "drop_key"),
+		}),
+		"scalar": tftypes.NewValue(tftypes.String, "passthrough"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: s, Raw: schemaObjectValue(t, s, map[string]tftypes.Value{
+					"name":   tftypes.NewValue(tftypes.String, "normalize"),
+					"config": config,
+				})},
+			}, resp)
+
+			if name == "object" {
+				assert.False(t, resp.Diagnostics.HasError(), "%s", resp.Diagnostics)
+				return
+			}
+			require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), "%s", resp.Diagnostics)
+			assert.Equal(t, "Invalid transform config", resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "an object with an `operations` list")
+		})
+	}
+}
+
+func TestResourceTransformValidateWithSample(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ResourceTransform{})
+
+	for _, tt := range []struct {
+		name          string
+		sandboxStatus int
+		wantError     string
+	}{
+		{name: "transform accepts the sample", sandboxStatus: http.StatusOK},
+		{name: "transform rejects the sample", sandboxStatus: http.StatusBadRequest, wantError: "Transform failed on the sample record"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var sandboxRequest map[string]any
+			var created bool
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v2/sandbox/transform":
+					_ = json.NewDecoder(r.Body).Decode(&sandboxRequest)
+					w.WriteHeader(tt.sandboxStatus)
+					if tt.sandboxStatus != http.StatusOK {
+						_, _ = w.Write([]byte(`{"error": "drop_key: missing argument key"}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"records": []}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/org/transforms":
+					created = true
+					_, _ = w.Write([]byte(`{"id": "tr-1"}`))
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			r := &ResourceTransform{client: c}
+
+			arguments := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"key": tftypes.String}}
+			operations := tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"operation": tftypes.String,
+				"arguments": arguments,
+			}}}}
+			config := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"operations": operations}}
+			sample := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"user": tftypes.String}}
+			value := schemaObjectValue(t, s, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "normalize"),
+				"config": tftypes.NewValue(config, map[string]tftypes.Value{
+					"operations": tftypes.NewValue(operations, []tftypes.Value{
+						tftypes.NewValue(operations.ElementTypes[0], map[string]tftypes.Value{
+							"operation": tftypes.NewValue(tftypes.String, "drop_key"),
+							"arguments": tftypes.NewValue(arguments, map[string]tftypes.Value{
+								"key": tftypes.NewValue(tftypes.String, "password"),
+							}),
+						}),
+					}),
+				}),
+				"validate_with_sample": tftypes.NewValue(sample, map[string]tftypes.Value{
+					"user": tftypes.NewValue(tftypes.String, "alice"),
+				}),
+			})
+
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullSchemaObjectValue(s)}}
+			r.Create(ctx, resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: value},
+				Plan:   tfsdk.Plan{Schema: s, Raw: value},
+			}, resp)
+
+			require.NotNil(t, sandboxRequest, "expected the transform to be run on the sample: %s", resp.Diagnostics)
+			record := make([]byte, 0)
+			for _, b := range sandboxRequest["record"].([]any) {
+				record = append(record, byte(b.(float64)))
+			}
+			assert.JSONEq(t, `{"user": "alice"}`, string(record))
+			assert.Equal(t, "drop_key", sandboxRequest["config"].(map[string]any)["operations"].([]any)[0].(map[string]any)["operation"])
+
+			if tt.wantError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantError, resp.Diagnostics.Errors()[0].Summary())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "missing argument key")
+				assert.False(t, created, "a transform that fails on the sample must not be created")
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%s", resp.Diagnostics)
+			assert.True(t, created)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceTimeouts is the `timeouts` block of a long-running resource.
+type ResourceTimeouts struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock is the `timeouts` block accepted by resources whose API calls
+// can take longer than the client's default request timeout.
+func timeoutsBlock() schema.SingleNestedBlock {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"How long %s may take, as a duration such as `10m`. When unset, each API request is limited to one minute.",
+				operation,
+			),
+			Optional:   true,
+			Validators: []validator.String{durationValidator{}},
+		}
+	}
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Operation timeouts",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("creating the resource"),
+			"read":   attribute("refreshing the resource"),
+			"update": attribute("updating the resource"),
+			"delete": attribute("deleting the resource"),
+		},
+	}
+}
+
+// withTimeout returns ctx bounded by the configured timeout for operation
+// ("create", "read", "update" or "delete"). Without one, ctx is returned
+// as-is and each request gets client.DefaultRequestTimeout.
+func withTimeout(ctx context.Context, diags *diag.Diagnostics, t *ResourceTimeouts, operation string) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return ctx, func() {}
+	}
+
+	value := map[string]types.String{
+		"create": t.Create,
+		"read":   t.Read,
+		"update": t.Update,
+		"delete": t.Delete,
+	}[operation]
+	if value.IsNull() || value.IsUnknown() {
+		return ctx, func() {}
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid timeout",
+			fmt.Sprintf("%q is not a positive duration such as \"10m\".", value.ValueString()),
+		)
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// durationValidator requires a string to be a positive Go duration.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as \"30s\" or \"10m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timeout",
+			fmt.Sprintf("%q is not a positive duration such as \"10m\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+	timeouts := &ResourceTimeouts{
+		Create: types.StringValue("10m"),
+		Read:   types.StringNull(),
+		Update: types.StringValue("soon"),
+		Delete: types.StringNull(),
+	}
+
+	var diags diag.Diagnostics
+	if got, cancel := withTimeout(ctx, &diags, nil, "create"); got != ctx {
+		t.Error("expected no timeouts block to leave the context unchanged")
+	} else {
+		cancel()
+	}
+	if got, cancel := withTimeout(ctx, &diags, timeouts, "read"); got != ctx {
+		t.Error("expected an unset timeout to leave the context unchanged")
+	} else {
+		cancel()
+	}
+
+	got, cancel := withTimeout(ctx, &diags, timeouts, "create")
+	defer cancel()
+	if deadline, ok := got.Deadline(); !ok || time.Until(deadline) > 10*time.Minute || time.Until(deadline) < 9*time.Minute {
+		t.Errorf("expected a deadline 10m out, got %v (set: %t)", deadline, ok)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics %s", diags)
+	}
+
+	_, cancel = withTimeout(ctx, &diags, timeouts, "update")
+	defer cancel()
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid timeout" {
+		t.Errorf("expected an invalid timeout error, got %s", diags)
+	}
+}
+
+func TestResourceTimeoutExceeded(t *testing.T) {
+	ctx := context.Background()
+	r := &ResourceOutput{client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})}
+	s := resourceSchema(t, r)
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutsType := objType.AttributeTypes["timeouts"].(tftypes.Object)
+
+	state := schemaObjectValue(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "out-1"),
+		"name": tftypes.NewValue(tftypes.String, "siem"),
+		"type": tftypes.NewValue(tftypes.String, httpOutputType),
+		"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, nil),
+			"read":   tftypes.NewValue(tftypes.String, nil),
+			"update": tftypes.NewValue(tftypes.String, nil),
+			"delete": tftypes.NewValue(tftypes.String, "50ms"),
+		}),
+	})
+
+	start := time.Now()
+	resp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: state}}, resp)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the delete timeout to cut the call short, took %s", elapsed)
+	}
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Operation Timed Out" {
+		t.Errorf("expected an operation timed out error, got %s", resp.Diagnostics)
+	}
+}
+
+// TestResourceTimeoutPerOperation checks that each operation gets its own
+// budget: a short `read` timeout cuts a slow refresh short, while a delete
+// with no timeout of its own waits for the same slow API.
+func TestResourceTimeoutPerOperation(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		kind     string
+		new      func() resource.Resource
+		path     string
+		response string
+	}{
+		{kind: "transform", new: NewResourceTransform, path: "/api/v1/org/transforms/res-1", response: `{"id": "res-1", "name": "slow"}`},
+		{kind: "secret", new: NewResourceSecret, path: "/api/v2/org/secrets/res-1", response: `{"id": "res-1", "name": "slow"}`},
+	} {
+		t.Run(tc.kind, func(t *testing.T) {
+			var gotPath string
+			c := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				gotPath = req.URL.Path
+				select {
+				case <-req.Context().Done():
+					return
+				case <-time.After(200 * time.Millisecond):
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.response))
+			})
+			r := tc.new()
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &resource.ConfigureResponse{})
+			s := resourceSchema(t, r)
+			objType := s.Type().TerraformType(ctx).(tftypes.Object)
+			timeoutsType := objType.AttributeTypes["timeouts"].(tftypes.Object)
+
+			state := tfsdk.State{Schema: s, Raw: schemaObjectValue(t, s, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "res-1"),
+				"name": tftypes.NewValue(tftypes.String, "slow"),
+				"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+					"create": tftypes.NewValue(tftypes.String, nil),
+					"read":   tftypes.NewValue(tftypes.String, "20ms"),
+					"update": tftypes.NewValue(tftypes.String, nil),
+					"delete": tftypes.NewValue(tftypes.String, nil),
+				}),
+			})}
+
+			readResp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+			if readResp.Diagnostics.ErrorsCount() != 1 || readResp.Diagnostics.Errors()[0].Summary() != "Operation Timed Out" {
+				t.Errorf("read: expected an operation timed out error, got %s", readResp.Diagnostics)
+			}
+			if gotPath != tc.path {
+				t.Errorf("read: expected a request to %s, got %s", tc.path, gotPath)
+			}
+
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Errorf("delete: expected the default budget to outlast the API, got %s", deleteResp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	ctx := context.Background()
+	timeoutPath := path.Root("timeouts").AtName("create")
+
+	for _, tc := range []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{value: types.StringValue("90s")},
+		{value: types.StringValue("1h30m")},
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+		{value: types.StringValue("ten minutes"), wantErr: true},
+		{value: types.StringValue("0s"), wantErr: true},
+	} {
+		resp := &validator.StringResponse{}
+		durationValidator{}.ValidateString(ctx, validator.StringRequest{Path: timeoutPath, ConfigValue: tc.value}, resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %s", tc.value, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
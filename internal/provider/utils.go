@@ -4,27 +4,146 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	monad "github.com/monad-inc/sdk/go"
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
 )
 
-func getResponseBody(resp *http.Response) []byte {
+// defaultMaxErrorBodyBytes is how much of a response body getResponseBody
+// quotes when the provider's `max_error_body_bytes` is not set.
+const defaultMaxErrorBodyBytes = 4096
+
+// getResponseBody returns the body of an API response for quoting in an error
+// diagnostic, truncated to c's MaxErrorBodyBytes (the provider's
+// `max_error_body_bytes`) so a large error page does not swamp the message.
+func getResponseBody(c *client.Client, resp *http.Response) string {
 	if resp == nil || resp.Body == nil {
-		return nil
+		return ""
 	}
 	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	return body
+	limit := defaultMaxErrorBodyBytes
+	if c != nil && c.MaxErrorBodyBytes > 0 {
+		limit = c.MaxErrorBodyBytes
+	}
+	// Read one byte past the limit to know whether anything was cut.
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	return truncateSnippet(body, limit)
+}
+
+// maxResponseSnippet bounds how much of an undecodable response body is quoted
+// in a diagnostic.
+const maxResponseSnippet = 512
+
+// addClientError reports a failed API call made to perform action, e.g.
+// "create output". A call cut short by its deadline says so instead of
+// quoting an empty response.
+func addClientError(c *client.Client, diags *diag.Diagnostics, action string, err error, resp *http.Response) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		diags.AddError(
+			"Operation Timed Out",
+			fmt.Sprintf(
+				"Unable to %s before the timeout expired: %s. If the operation needs more time, "+
+					"set a longer duration in the resource's `timeouts` block.",
+				action,
+				err,
+			),
+		)
+		return
+	}
+
+	diags.AddError(
+		"Client Error",
+		fmt.Sprintf(
+			"Unable to %s, got error: %s. Response: %s",
+			action,
+			err,
+			getResponseBody(c, resp),
+		),
+	)
+}
+
+// addReadError reports a failed Read of the given kind of resource. A 2xx
+// response that still errored means the SDK could not decode the body (usually
+// schema drift between the API and the SDK), so that case names the endpoint
+// and resource id and quotes the start of the body. Anything else keeps the
+// generic client error.
+func addReadError(c *client.Client, diags *diag.Diagnostics, kind, id string, err error, resp *http.Response) {
+	var apiErr *monad.GenericOpenAPIError
+	if resp == nil || resp.StatusCode >= 300 || !errors.As(err, &apiErr) {
+		addClientError(c, diags, "read "+kind, err, resp)
+		return
+	}
+
+	endpoint := "the Monad API"
+	if resp.Request != nil && resp.Request.URL != nil {
+		endpoint = resp.Request.Method + " " + resp.Request.URL.Path
+	}
+
+	diags.AddError(
+		"Unexpected API Response",
+		fmt.Sprintf(
+			"Unable to decode %s %q returned by %s, got error: %s. "+
+				"The API response may not match this provider version. Response: %s",
+			kind,
+			id,
+			endpoint,
+			apiErr.Error(),
+			truncateSnippet(apiErr.Body(), maxResponseSnippet),
+		),
+	)
+}
+
+// truncateSnippet returns at most limit bytes of body as valid UTF-8, marking
+// the cut when anything was dropped.
+func truncateSnippet(body []byte, limit int) string {
+	if len(body) <= limit {
+		return string(body)
+	}
+	return strings.ToValidUTF8(string(body[:limit]), "") + "... (truncated)"
+}
+
+// requireKnown reports an error against p when v, or any value nested in it,
+// is still unknown. Create and Update call it on the attributes they send, so
+// a value that is unknown at apply time fails loudly instead of reaching the
+// API as an empty string.
+func requireKnown(ctx context.Context, diags *diag.Diagnostics, p path.Path, v attr.Value) {
+	tfv, err := v.ToTerraformValue(ctx)
+	if err == nil && tfv.IsFullyKnown() {
+		return
+	}
+	diags.AddAttributeError(
+		p,
+		"Unknown Value",
+		fmt.Sprintf(
+			"%s is not known at apply time, so it cannot be sent to the Monad API. "+
+				"This is usually a bug in the provider or in a resource it depends on.",
+			p,
+		),
+	)
 }
 
 // hmacSHA256Hex computes an HMAC-SHA256 of value keyed by key, returned as a
@@ -73,6 +192,87 @@ func computeSecretsHash(ctx context.Context, orgID string, secrets map[string]an
 	return hmacSHA256Hex(ctx, secretsHashKey(orgID), string(encoded)), nil
 }
 
+// redactedValue replaces secret values in logged maps.
+const redactedValue = "(redacted)"
+
+// defaultSecretKeyFragments are the key fragments redactSecrets treats as
+// sensitive. Keys are matched case-insensitively, with `-` folded to `_`, and
+// a key is sensitive when it contains any fragment. Extra fragments can be
+// supplied as a comma-separated MONAD_LOG_REDACT_KEYS.
+var defaultSecretKeyFragments = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"api_key",
+	"apikey",
+	"private_key",
+	"access_key",
+	"credential",
+	"authorization",
+	"connection_string",
+}
+
+// secretKeyFragments returns the default fragments plus any configured via
+// MONAD_LOG_REDACT_KEYS.
+func secretKeyFragments() []string {
+	fragments := append([]string{}, defaultSecretKeyFragments...)
+	for _, f := range strings.Split(os.Getenv("MONAD_LOG_REDACT_KEYS"), ",") {
+		if f = normalizeSecretKey(f); f != "" {
+			fragments = append(fragments, f)
+		}
+	}
+	return fragments
+}
+
+func normalizeSecretKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+}
+
+func isSecretKey(key string, fragments []string) bool {
+	normalized := normalizeSecretKey(key)
+	for _, f := range fragments {
+		if strings.Contains(normalized, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecrets returns a deep copy of in with the value under every
+// secret-like key (see defaultSecretKeyFragments) replaced by a placeholder.
+// Any tflog call that dumps settings or config must pass it through here.
+func redactSecrets(in map[string]any) map[string]any {
+	if in == nil {
+		return nil
+	}
+	redacted, _ := redactValue(in, secretKeyFragments()).(map[string]any)
+	return redacted
+}
+
+func redactValue(v any, fragments []string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			if val != nil && isSecretKey(k, fragments) {
+				out[k] = redactedValue
+				continue
+			}
+			out[k] = redactValue(val, fragments)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, e := range t {
+			out[i] = redactValue(e, fragments)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
 // dynamicsSemanticallyEqual reports whether two dynamic values carry the same
 // data regardless of their concrete cty types. A practitioner's jsondecode
 // yields tuples/objects while an API-derived value yields lists/maps; the two
@@ -199,6 +399,38 @@ func tfDynamicToMapAny(dyn types.Dynamic) (map[string]any, error) {
 	}
 }
 
+// conversionError is a failure to convert a value nested inside settings or
+// secrets, located by its path from the top-level value, e.g.
+// `pagination.fields[2]`.
+type conversionError struct {
+	steps []string
+	err   error
+}
+
+func (e *conversionError) Error() string {
+	var b strings.Builder
+	for i, step := range e.steps {
+		if i > 0 && !strings.HasPrefix(step, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(step)
+	}
+	return fmt.Sprintf("%s: %s", b.String(), e.err)
+}
+
+func (e *conversionError) Unwrap() error { return e.err }
+
+// atPathStep prefixes the path of a conversion error with step, an object key
+// or a "[i]" index, as the error returns through each level of nesting.
+func atPathStep(step string, err error) error {
+	var convErr *conversionError
+	if errors.As(err, &convErr) {
+		convErr.steps = append([]string{step}, convErr.steps...)
+		return convErr
+	}
+	return &conversionError{steps: []string{step}, err: err}
+}
+
 func tfObjectToMapAny(ctx context.Context, obj types.Object) (map[string]any, error) {
 	if obj.IsNull() || obj.IsUnknown() {
 		return nil, nil
@@ -210,7 +442,7 @@ func tfObjectToMapAny(ctx context.Context, obj types.Object) (map[string]any, er
 	for key, attrValue := range attrs {
 		converted, err := tfValueToAny(ctx, attrValue)
 		if err != nil {
-			return nil, fmt.Errorf("error converting attribute %q: %w", key, err)
+			return nil, atPathStep(key, err)
 		}
 		result[key] = converted
 	}
@@ -229,7 +461,7 @@ func tfMapToMapAny(ctx context.Context, mapVal types.Map) (map[string]any, error
 	for key, element := range elements {
 		converted, err := tfValueToAny(ctx, element)
 		if err != nil {
-			return nil, fmt.Errorf("error converting map element %q: %w", key, err)
+			return nil, atPathStep(key, err)
 		}
 		result[key] = converted
 	}
@@ -237,6 +469,10 @@ func tfMapToMapAny(ctx context.Context, mapVal types.Map) (map[string]any, error
 	return result, nil
 }
 
+// tfValueToAny converts a Terraform value to its Go equivalent. Strings are
+// returned as-is: a time or base64 blob that went through anyToAttrValue is
+// indistinguishable from any other string, so it is sent back in that form,
+// which is also what the API accepts.
 func tfValueToAny(ctx context.Context, value attr.Value) (any, error) {
 	if value.IsNull() {
 		return nil, nil
@@ -285,7 +521,7 @@ func tfListToSliceAny(ctx context.Context, list types.List) ([]any, error) {
 	for i, element := range elements {
 		converted, err := tfValueToAny(ctx, element)
 		if err != nil {
-			return nil, fmt.Errorf("error converting list element at index %d: %w", i, err)
+			return nil, atPathStep(fmt.Sprintf("[%d]", i), err)
 		}
 		result[i] = converted
 	}
@@ -305,7 +541,7 @@ func tfSetToSliceAny(ctx context.Context, set types.Set) ([]any, error) {
 	for _, element := range elements {
 		converted, err := tfValueToAny(ctx, element)
 		if err != nil {
-			return nil, fmt.Errorf("error converting set element: %w", err)
+			return nil, atPathStep(fmt.Sprintf("[%d]", i), err)
 		}
 		result[i] = converted
 		i++
@@ -325,7 +561,7 @@ func tfTupleToSliceAny(ctx context.Context, tuple types.Tuple) ([]any, error) {
 	for i, element := range elements {
 		converted, err := tfValueToAny(ctx, element)
 		if err != nil {
-			return nil, fmt.Errorf("error converting tuple element at index %d: %w", i, err)
+			return nil, atPathStep(fmt.Sprintf("[%d]", i), err)
 		}
 		result[i] = converted
 	}
@@ -333,6 +569,14 @@ func tfTupleToSliceAny(ctx context.Context, tuple types.Tuple) ([]any, error) {
 	return result, nil
 }
 
+// numberAttrValue converts a JSON-decoded float, keeping whole numbers Int64.
+func numberAttrValue(f float64) (attr.Value, attr.Type) {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return types.Int64Value(int64(f)), types.Int64Type
+	}
+	return types.Float64Value(f), types.Float64Type
+}
+
 // anyToAttrValue converts a Go value to an attr.Value and attr.Type
 func anyToAttrValue(v any) (attr.Value, attr.Type, error) {
 	if v == nil {
@@ -351,9 +595,17 @@ func anyToAttrValue(v any) (attr.Value, attr.Type, error) {
 	case int64:
 		return types.Int64Value(val), types.Int64Type, nil
 	case float32:
-		return types.Float64Value(float64(val)), types.Float64Type, nil
+		value, valueType := numberAttrValue(float64(val))
+		return value, valueType, nil
 	case float64:
-		return types.Float64Value(val), types.Float64Type, nil
+		value, valueType := numberAttrValue(val)
+		return value, valueType, nil
+	case time.Time:
+		// Terraform has no time type; RFC 3339 is what the API accepts back.
+		return types.StringValue(val.Format(time.RFC3339)), types.StringType, nil
+	case []byte:
+		// Binary values travel as base64, matching encoding/json.
+		return types.StringValue(base64.StdEncoding.EncodeToString(val)), types.StringType, nil
 	case []any:
 		// Convert slice to tuple (which can handle heterogeneous types)
 		elements := make([]attr.Value, len(val))
@@ -362,7 +614,7 @@ func anyToAttrValue(v any) (attr.Value, attr.Type, error) {
 		for i, elem := range val {
 			elemValue, elemType, err := anyToAttrValue(elem)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error converting slice element at index %d: %w", i, err)
+				return nil, nil, atPathStep(fmt.Sprintf("[%d]", i), err)
 			}
 			elements[i] = elemValue
 			elementTypes[i] = elemType
@@ -391,7 +643,7 @@ func anyToAttrValue(v any) (attr.Value, attr.Type, error) {
 		for key, value := range val {
 			attrValue, attrType, err := anyToAttrValue(value)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error converting map value for key %q: %w", key, err)
+				return nil, nil, atPathStep(key, err)
 			}
 			attributes[key] = attrValue
 			attributeTypes[key] = attrType
@@ -416,16 +668,39 @@ func anyToAttrValue(v any) (attr.Value, attr.Type, error) {
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			return types.Int64Value(int64(rv.Uint())), types.Int64Type, nil
 		case reflect.Float32, reflect.Float64:
-			return types.Float64Value(rv.Float()), types.Float64Type, nil
+			value, valueType := numberAttrValue(rv.Float())
+			return value, valueType, nil
+		case reflect.Struct:
+			// SDK structs are converted through their JSON encoding, which
+			// renders nested time.Time as RFC 3339 and []byte as base64.
+			asMap, err := structToMapAny(v)
+			if err != nil {
+				return nil, nil, err
+			}
+			return anyToAttrValue(asMap)
 		default:
 			return nil, nil, fmt.Errorf("unsupported Go type: %T (kind: %s)", v, rv.Kind())
 		}
 	}
 }
 
-// AnyToDynamic converts a map[string]any to types.Dynamic
+// structToMapAny converts a struct to a map[string]any via its JSON encoding.
+func structToMapAny(v any) (map[string]any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %T: %w", v, err)
+	}
+	out := make(map[string]any)
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		return nil, fmt.Errorf("error decoding %T: %w", v, err)
+	}
+	return out, nil
+}
+
+// AnyToDynamic converts a map[string]any to types.Dynamic. A nil map is null;
+// an empty one is an empty object, so a configured `{}` reads back as written.
 func AnyToDynamic(in map[string]any) (types.Dynamic, error) {
-	if len(in) == 0 {
+	if in == nil {
 		return types.DynamicNull(), nil
 	}
 
@@ -438,3 +713,99 @@ func AnyToDynamic(in map[string]any) (types.Dynamic, error) {
 	// Wrap the ObjectValue in a DynamicValue
 	return types.DynamicValue(attrValue), nil
 }
+
+// stringOrNull maps an API string to a Terraform value. The API reports an
+// unset optional string as absent or empty; both read back as null so they
+// match an omitted attribute instead of diffing against "".
+func stringOrNull(s *string) types.String {
+	if s == nil || *s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*s)
+}
+
+// refreshDescription is stringOrNull for a Read with prior state. An empty
+// API description keeps a prior "", so `description = ""` in config reads back
+// as written instead of as null, which would show a diff on every plan. The
+// plan itself cannot normalize "" to null: Terraform requires a configured
+// value to be planned unchanged.
+func refreshDescription(prior types.String, api *string) types.String {
+	if (api == nil || *api == "") && !prior.IsUnknown() && !prior.IsNull() && prior.ValueString() == "" {
+		return prior
+	}
+	return stringOrNull(api)
+}
+
+// updateDescription is the `description` sent by an Update. The API keeps the
+// stored description when the field is omitted, so a null description is sent
+// as "" to clear it.
+func updateDescription(description types.String) *string {
+	if description.IsNull() {
+		empty := ""
+		return &empty
+	}
+	return description.ValueStringPointer()
+}
+
+// resolveOrganizationID returns the organization a resource's API calls are
+// made against: its own `organization_id` when known, otherwise the
+// provider's.
+func resolveOrganizationID(c *client.Client, override types.String) string {
+	if !override.IsNull() && !override.IsUnknown() && override.ValueString() != "" {
+		return override.ValueString()
+	}
+	return c.OrganizationID
+}
+
+// organizationIDAttribute is the `organization_id` override shared by every
+// resource. Moving a resource to another organization replaces it.
+func organizationIDAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Organization the %s belongs to. Defaults to the provider's `organization_id`.", kind),
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// plannedOrganizationID is resolveOrganizationID for a plan, whose
+// `organization_id` is unknown until the first apply.
+func plannedOrganizationID(ctx context.Context, c *client.Client, plan tfsdk.Plan, diags *diag.Diagnostics) string {
+	if plan.Raw.IsNull() {
+		return c.OrganizationID
+	}
+	var override types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("organization_id"), &override)...)
+	return resolveOrganizationID(c, override)
+}
+
+// applyDefaultDescription plans the `description` of a resource whose config
+// leaves it null. `description` is Optional+Computed so the provider-level
+// default_description can be planned: it is then sent to the API and stored
+// like an explicit value. Without a default the planned value is null, as it
+// would be for a plain Optional attribute, so removing `description` from
+// config clears it. A description unknown in config is left for apply.
+func applyDefaultDescription(ctx context.Context, c *client.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A planned destroy has a null plan; nothing to reconcile.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	descriptionPath := path.Root("description")
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, descriptionPath, &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	planned := types.StringNull()
+	if c != nil && c.DefaultDescription != "" {
+		planned = types.StringValue(c.DefaultDescription)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, descriptionPath, planned)...)
+}
@@ -3,13 +3,22 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"math/big"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	monad "github.com/monad-inc/sdk/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/monad-inc/terraform-provider-monad/internal/provider/client"
 )
 
 func TestTfDynamicToMapAny(t *testing.T) {
@@ -258,9 +267,8 @@ func TestAnyToDynamic(t *testing.T) {
 			wantNull: true,
 		},
 		{
-			name:     "empty map",
-			input:    map[string]any{},
-			wantNull: true,
+			name:  "empty map",
+			input: map[string]any{},
 		},
 		{
 			name: "string values",
@@ -997,4 +1005,246 @@ func TestAnyToAttrValue_ErrorCases(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestAnyToAttrValue_TimeAndBytes(t *testing.T) {
+	ts := time.Date(2025, 7, 11, 17, 39, 42, 0, time.UTC)
+
+	t.Run("time.Time as RFC 3339 string", func(t *testing.T) {
+		result, attrType, err := anyToAttrValue(ts)
+		require.NoError(t, err)
+		assert.Equal(t, types.StringType, attrType)
+		assert.Equal(t, types.StringValue("2025-07-11T17:39:42Z"), result)
+	})
+
+	t.Run("[]byte as base64 string", func(t *testing.T) {
+		result, attrType, err := anyToAttrValue([]byte("monad"))
+		require.NoError(t, err)
+		assert.Equal(t, types.StringType, attrType)
+		assert.Equal(t, types.StringValue("bW9uYWQ="), result)
+	})
+
+	t.Run("struct containing a time and a byte slice", func(t *testing.T) {
+		in := struct {
+			CreatedAt time.Time `json:"created_at"`
+			Blob      []byte    `json:"blob"`
+			Name      string    `json:"name"`
+		}{
+			CreatedAt: ts,
+			Blob:      []byte("monad"),
+			Name:      "settings",
+		}
+
+		result, _, err := anyToAttrValue(in)
+		require.NoError(t, err)
+
+		obj, ok := result.(types.Object)
+		require.True(t, ok, "expected types.Object, got %T", result)
+
+		back, err := tfObjectToMapAny(context.Background(), obj)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"created_at": "2025-07-11T17:39:42Z",
+			"blob":       "bW9uYWQ=",
+			"name":       "settings",
+		}, back)
+	})
+}
+
+func TestRedactSecrets(t *testing.T) {
+	in := map[string]any{
+		"endpoint": "https://example.com",
+		"password": "hunter2",
+		"API-Key":  "abc",
+		"auth": map[string]any{
+			"username":     "svc",
+			"access_token": "xyz",
+		},
+		"headers": []any{
+			map[string]any{"name": "X-Trace", "client_secret": "s3cr3t"},
+		},
+		"token_ttl_token": nil,
+		"batch_size":      100,
+	}
+
+	got := redactSecrets(in)
+
+	assert.Equal(t, map[string]any{
+		"endpoint": "https://example.com",
+		"password": redactedValue,
+		"API-Key":  redactedValue,
+		"auth": map[string]any{
+			"username":     "svc",
+			"access_token": redactedValue,
+		},
+		"headers": []any{
+			map[string]any{"name": "X-Trace", "client_secret": redactedValue},
+		},
+		"token_ttl_token": nil,
+		"batch_size":      100,
+	}, got)
+
+	// The input is deep-copied, never modified.
+	assert.Equal(t, "hunter2", in["password"])
+	assert.Equal(t, "xyz", in["auth"].(map[string]any)["access_token"])
+
+	t.Run("extra fragments from MONAD_LOG_REDACT_KEYS", func(t *testing.T) {
+		t.Setenv("MONAD_LOG_REDACT_KEYS", "Account-ID, ")
+		got := redactSecrets(map[string]any{"account_id": "123", "region": "us-east-1"})
+		assert.Equal(t, map[string]any{"account_id": redactedValue, "region": "us-east-1"}, got)
+	})
+}
+
+func TestConversionErrorsNameThePath(t *testing.T) {
+	t.Run("settings with a nested unknown value", func(t *testing.T) {
+		fields := types.TupleValueMust(
+			[]attr.Type{types.StringType, types.StringType},
+			[]attr.Value{types.StringValue("severity"), types.StringUnknown()},
+		)
+		pagination := types.ObjectValueMust(
+			map[string]attr.Type{"fields": fields.Type(context.Background())},
+			map[string]attr.Value{"fields": fields},
+		)
+		settings := types.ObjectValueMust(
+			map[string]attr.Type{"pagination": pagination.Type(context.Background())},
+			map[string]attr.Value{"pagination": pagination},
+		)
+		data := ResourceConnectorModel{
+			Config: &ResourceConnectorConfig{Settings: types.DynamicValue(settings), Secrets: types.DynamicNull()},
+		}
+
+		_, _, err := data.getSettingsAndSecrets()
+		require.Error(t, err)
+		assert.Equal(t, "config.settings.pagination.fields[1]: cannot convert unknown value to any", err.Error())
+	})
+
+	t.Run("api value with a nested channel", func(t *testing.T) {
+		_, err := AnyToDynamic(map[string]any{
+			"pagination": map[string]any{"hooks": []any{"ok", make(chan int)}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pagination.hooks[1]: unsupported Go type: chan int (kind: chan)")
+	})
+}
+
+func TestGetResponseBodyTruncatesLargeBodies(t *testing.T) {
+	response := func(body string) *http.Response {
+		return &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+	}
+	large := strings.Repeat("x", 10*defaultMaxErrorBodyBytes)
+
+	got := getResponseBody(&client.Client{}, response(large))
+	if want := strings.Repeat("x", defaultMaxErrorBodyBytes) + "... (truncated)"; got != want {
+		t.Errorf("expected the default cap of %d bytes, got %d bytes", defaultMaxErrorBodyBytes, len(got))
+	}
+	if got := getResponseBody(nil, response(large)); len(got) != len(strings.Repeat("x", defaultMaxErrorBodyBytes)+"... (truncated)") {
+		t.Errorf("expected the default cap without a client, got %d bytes", len(got))
+	}
+
+	c := &client.Client{MaxErrorBodyBytes: 16}
+	if got := getResponseBody(c, response(large)); got != strings.Repeat("x", 16)+"... (truncated)" {
+		t.Errorf("expected the configured cap, got %q", got)
+	}
+	if got := getResponseBody(c, response(`{"error":"bad"}`)); got != `{"error":"bad"}` {
+		t.Errorf("expected a short body unchanged, got %q", got)
+	}
+	if got := getResponseBody(c, nil); got != "" {
+		t.Errorf("expected no body for a nil response, got %q", got)
+	}
+}
+
+func TestRefreshDescription(t *testing.T) {
+	empty, text := "", "Managed by Terraform"
+	cases := []struct {
+		name  string
+		prior types.String
+		api   *string
+		want  types.String
+	}{
+		{name: "configured empty, API empty", prior: types.StringValue(""), api: &empty, want: types.StringValue("")},
+		{name: "configured empty, API absent", prior: types.StringValue(""), api: nil, want: types.StringValue("")},
+		{name: "omitted, API empty", prior: types.StringNull(), api: &empty, want: types.StringNull()},
+		{name: "import", prior: types.StringNull(), api: &text, want: types.StringValue(text)},
+		{name: "drift from empty", prior: types.StringValue(""), api: &text, want: types.StringValue(text)},
+		{name: "cleared on the server", prior: types.StringValue(text), api: &empty, want: types.StringNull()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, refreshDescription(tc.prior, tc.api))
+		})
+	}
+}
+
+func TestUpdateDescription(t *testing.T) {
+	assert.Equal(t, "", *updateDescription(types.StringNull()))
+	assert.Equal(t, "payments token", *updateDescription(types.StringValue("payments token")))
+}
+
+func TestAnyToAttrValueWholeNumbersStayIntegers(t *testing.T) {
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"limit": 5, "ratio": 0.5, "huge": 1e300}`), &decoded))
+
+	dyn, err := AnyToDynamic(decoded)
+	require.NoError(t, err)
+	attrs := dyn.UnderlyingValue().(types.Object).Attributes()
+	assert.Equal(t, types.Int64Value(5), attrs["limit"])
+	assert.Equal(t, types.Float64Value(0.5), attrs["ratio"])
+	assert.Equal(t, types.Float64Value(1e300), attrs["huge"], "a whole number beyond int64 stays a float")
+
+	// A transform config written with `limit = 5` and echoed by the API is not
+	// drift.
+	configured, err := AnyToDynamic(map[string]any{"operations": []any{
+		map[string]any{"operation": "limit", "arguments": map[string]any{"limit": 5}},
+	}})
+	require.NoError(t, err)
+	apiConfig, err := transformConfigToMap(&monad.ModelsTransformConfig{
+		Operations: []monad.ModelsTransformOperation{{
+			Operation: monad.PtrString("limit"),
+			Arguments: map[string]any{"limit": 5},
+		}},
+	}, nil)
+	require.NoError(t, err)
+	fromAPI, err := AnyToDynamic(apiConfig)
+	require.NoError(t, err)
+	assert.True(t, fromAPI.Equal(configured), "got %s, want %s", fromAPI, configured)
+}
+
+func TestAddClientError(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(nil, &diags, "create output", errors.New("connection refused"), nil)
+	require.Equal(t, 1, diags.ErrorsCount())
+	assert.Equal(t, "Client Error", diags.Errors()[0].Summary())
+	assert.Equal(t, "Unable to create output, got error: connection refused. Response: ", diags.Errors()[0].Detail())
+}
+func TestAnyToAttrValueObjectsWithDifferentKeys(t *testing.T) {
+	operations := []any{
+		map[string]any{"operation": "drop_key", "arguments": map[string]any{"key": "password"}},
+		map[string]any{"operation": "rename_key", "arguments": map[string]any{"old": "src", "new": "source_ip"}},
+		map[string]any{"operation": "flatten", "arguments": map[string]any{}},
+	}
+
+	value, valueType, err := anyToAttrValue(operations)
+	require.NoError(t, err)
+
+	// Each element keeps its own object type, which a list could not hold.
+	tuple, ok := value.(types.Tuple)
+	require.True(t, ok, "expected a tuple, got %T", value)
+	elemTypes := valueType.(types.TupleType).ElemTypes
+	require.Len(t, elemTypes, 3)
+	assert.False(t, elemTypes[0].Equal(elemTypes[1]), "expected per-element object types")
+	for i, elem := range tuple.Elements() {
+		assert.True(t, elem.Type(context.Background()).Equal(elemTypes[i]), "element %d", i)
+	}
+
+	// The operations survive a transform config round trip unchanged.
+	config := map[string]any{"operations": operations}
+	dyn, err := AnyToDynamic(config)
+	require.NoError(t, err)
+	got, err := parseTransformConfig(context.Background(), dyn)
+	require.NoError(t, err)
+	require.Len(t, got.Operations, 3)
+	assert.Equal(t, map[string]any{"old": "src", "new": "source_ip"}, *got.Operations[1].Arguments.MapmapOfStringAny)
+
+	back, err := tfDynamicToMapAny(dyn)
+	require.NoError(t, err)
+	assert.Equal(t, config, back)
+}
@@ -0,0 +1,929 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compression codecs accepted by outputs that write encoded batches.
+const (
+	compressionNone = "none"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+// Record formats accepted by outputs that write encoded batches.
+const (
+	formatNDJSON  = "ndjson"
+	formatJSON    = "json"
+	formatParquet = "parquet"
+)
+
+// Kinesis shard iterator types a stream input can start reading from.
+const (
+	shardIteratorTrimHorizon = "TRIM_HORIZON"
+	shardIteratorLatest      = "LATEST"
+	shardIteratorAtTimestamp = "AT_TIMESTAMP"
+)
+
+// HMAC algorithms a webhook output can sign payloads with.
+const (
+	hmacAlgorithmSHA256 = "sha256"
+	hmacAlgorithmSHA512 = "sha512"
+)
+
+var (
+	supportedCompressions   = []string{compressionNone, compressionGzip, compressionZstd}
+	supportedFormats        = []string{formatNDJSON, formatJSON, formatParquet}
+	supportedShardIterators = []string{shardIteratorTrimHorizon, shardIteratorLatest, shardIteratorAtTimestamp}
+	supportedHMACAlgorithms = []string{hmacAlgorithmSHA256, hmacAlgorithmSHA512}
+)
+
+// connectorConfig is the decoded `config` block of a connector.
+type connectorConfig struct {
+	settings map[string]any
+	secrets  map[string]any
+}
+
+// connectorRule checks one aspect of a connector's config. Rules skip values
+// that are absent or not the expected Go type; the API has the final say.
+type connectorRule func(cfg connectorConfig) diag.Diagnostics
+
+// encodingRules apply to outputs that write compressed, encoded batches.
+var encodingRules = []connectorRule{
+	settingOneOf("compression", supportedCompressions...),
+	settingOneOf("format", supportedFormats...),
+}
+
+// outputRules maps an output `type` to the rules its config must satisfy.
+// Connector types do not get typed resources of their own: monad_output and
+// monad_input already refresh settings on Read and handle write-only secrets,
+// secrets_hash, drift and import for every type, so a type's own checks are
+// added here and in inputRules instead.
+var outputRules = map[string][]connectorRule{
+	"s3":                 encodingRules,
+	"gcs":                encodingRules,
+	"azure_blob_storage": encodingRules,
+	"kafka":              encodingRules,
+	"bigquery": {
+		secretJSON("service_account_json"),
+	},
+	"kinesis": {
+		settingRequired("partition_key_field"),
+	},
+	"pubsub": {
+		secretJSON("service_account_json"),
+	},
+	"clickhouse": {
+		settingPort("port"),
+	},
+	"mongodb": {
+		secretsExactlyOneOf(
+			[]string{"connection_string"},
+			[]string{"username", "password", "hosts"},
+		),
+	},
+	"opensearch": {
+		settingURLList("addresses"),
+		settingPositiveInt("batch_size"),
+		secretsAtMostOneOf(
+			[]string{"username", "password"},
+			[]string{"aws_access_key_id", "aws_secret_access_key"},
+		),
+	},
+	"azure_sentinel": {
+		settingURL("dce_endpoint"),
+	},
+	"loki": {
+		settingURL("endpoint"),
+		settingStringMap("labels"),
+		settingPositiveInt("batch_size"),
+	},
+	"sumologic": {
+		secretURL("collector_url"),
+	},
+	postgresqlOutputType: {
+		settingsTogether("host", "database", "table"),
+		settingPort("port"),
+		settingStringMap("column_mapping"),
+		settingsAtMostOneOf("column_names", "column_mapping"),
+		settingsRecommendOneOf(
+			"Without them the server's default column selection decides which record "+
+				"fields are written, and fields it does not select are dropped silently.",
+			"column_names", "column_mapping",
+		),
+	},
+	httpOutputType: {
+		settingRequiredWhen("payload_structure", payloadStructureWrapped, "wrapper_key"),
+		settingStringMap("headers_map"),
+		settingsAtMostOneOf("headers", "headers_map"),
+		settingPEM("ca_cert"),
+		secretPEM("client_cert"),
+		secretPEM("client_key"),
+		secretsAtMostOneOf([]string{"client_cert", "client_key"}),
+	},
+	"webhook": {
+		settingURL("url"),
+		settingOneOf("hmac_algorithm", supportedHMACAlgorithms...),
+		settingRequiresSecret("hmac_algorithm", "signing_key"),
+	},
+}
+
+// oktaRules apply to inputs that read from an Okta org.
+var oktaRules = []connectorRule{
+	settingOktaOrgURL("org_url"),
+}
+
+// oktaDomains are the hosting domains of Okta orgs.
+var oktaDomains = []string{"okta.com", "oktapreview.com", "okta-emea.com", "okta-gov.com"}
+
+// inputRules maps an input `type` to the rules its config must satisfy.
+var inputRules = map[string][]connectorRule{
+	"okta":           oktaRules,
+	"okta-systemlog": oktaRules,
+	"http_pull": {
+		settingURL("url"),
+		settingDuration("interval"),
+	},
+	"gcs": {
+		settingOneOf("compression", supportedCompressions...),
+		secretJSON("service_account_json"),
+	},
+	"azure_blob": {
+		secretsExactlyOneOf([]string{"account_key"}, []string{"sas_token"}),
+	},
+	"sqs": {
+		settingURL("queue_url"),
+		// SQS accepts visibility timeouts of 0 seconds to 12 hours.
+		settingIntRange("visibility_timeout", 0, 43200),
+	},
+	"pubsub": {
+		// Pub/Sub accepts acknowledgement deadlines of 10 to 600 seconds.
+		settingIntRange("ack_deadline", 10, 600),
+		secretJSON("service_account_json"),
+	},
+	"kinesis": {
+		settingOneOf("shard_iterator_type", supportedShardIterators...),
+	},
+}
+
+var (
+	settingsPath = path.Root("config").AtName("settings")
+	secretsPath  = path.Root("config").AtName("secrets")
+)
+
+// validateSettingOneOf reports an error at settingsPath when settings[key] is
+// a string outside allowed. Missing keys and non-string values (e.g. a nested
+// format object) are left for the API to validate.
+func validateSettingOneOf(settingsPath path.Path, settings map[string]any, key string, allowed []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, ok := settings[key].(string)
+	if !ok || slices.Contains(allowed, value) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		settingsPath,
+		fmt.Sprintf("Invalid %s", key),
+		fmt.Sprintf("%q is not a supported %s. Valid values are: %s.", value, key, strings.Join(allowed, ", ")),
+	)
+	return diags
+}
+
+// settingOneOf requires settings[key] to be one of allowed.
+func settingOneOf(key string, allowed ...string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		return validateSettingOneOf(settingsPath, cfg.settings, key, allowed)
+	}
+}
+
+// settingRequired requires settings[key] to be set to a non-blank string.
+func settingRequired(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, _ := cfg.settings[key].(string)
+		if strings.TrimSpace(value) == "" {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Missing %s", key),
+				fmt.Sprintf("The %s setting must be set to a non-empty value.", key),
+			)
+		}
+		return diags
+	}
+}
+
+// settingURL requires settings[key] to be an absolute http(s) URL.
+func settingURL(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key].(string)
+		if !ok {
+			return diags
+		}
+		if err := checkHTTPURL(value); err != nil {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting %q is not a valid URL: %s.", key, value, err),
+			)
+		}
+		return diags
+	}
+}
+
+// secretValue returns the plaintext of a connector secret: the secret itself
+// when it is a string, or the `value` of an inline `{ value }` secret.
+// References to existing secrets have no plaintext.
+func secretValue(secret any) (string, bool) {
+	if inline, ok := secret.(map[string]any); ok {
+		secret = inline[secretValueKey]
+	}
+	value, ok := secret.(string)
+	return value, ok
+}
+
+// secretURL requires secrets[key] to be an absolute http(s) URL, such as an
+// ingestion URL with an embedded token. The value is never echoed back.
+func secretURL(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := secretValue(cfg.secrets[key])
+		if !ok {
+			return diags
+		}
+		if err := checkHTTPURL(value); err != nil {
+			diags.AddAttributeError(
+				secretsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s secret is not a valid URL: %s.", key, err),
+			)
+		}
+		return diags
+	}
+}
+
+// settingURLList requires settings[key], when set, to be a non-empty list of
+// absolute http(s) URLs.
+func settingURLList(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key]
+		if !ok || value == nil {
+			return diags
+		}
+		list, ok := value.([]any)
+		if !ok || len(list) == 0 {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting must be a non-empty list of URLs.", key),
+			)
+			return diags
+		}
+		for i, item := range list {
+			s, _ := item.(string)
+			if err := checkHTTPURL(s); err != nil {
+				diags.AddAttributeError(
+					settingsPath,
+					fmt.Sprintf("Invalid %s", key),
+					fmt.Sprintf("Entry %d of the %s setting, %q, is not a valid URL: %s.", i, key, s, err),
+				)
+			}
+		}
+		return diags
+	}
+}
+
+// settingStringMap requires settings[key], when set, to be a map of strings,
+// such as a set of labels.
+func settingStringMap(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key]
+		if !ok || value == nil {
+			return diags
+		}
+		m, ok := value.(map[string]any)
+		if ok {
+			for _, v := range m {
+				if _, ok = v.(string); !ok {
+					break
+				}
+			}
+		}
+		if !ok {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting must be a map of strings.", key),
+			)
+		}
+		return diags
+	}
+}
+
+// checkHTTPURL reports why value is not an absolute http or https URL.
+func checkHTTPURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host is missing")
+	}
+	return nil
+}
+
+// settingOktaOrgURL requires settings[key] to be the https URL of an Okta
+// org, such as "https://example.okta.com", with no path.
+func settingOktaOrgURL(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key].(string)
+		if !ok {
+			return diags
+		}
+		if err := checkOktaOrgURL(value); err != nil {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting %q is not an Okta org URL such as \"https://example.okta.com\": %s.", key, value, err),
+			)
+		}
+		return diags
+	}
+}
+
+// checkOktaOrgURL reports why value is not the https URL of an Okta org.
+func checkOktaOrgURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("scheme must be https")
+	}
+	if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+		return fmt.Errorf("it must not have a path or query")
+	}
+	host := u.Hostname()
+	for _, domain := range oktaDomains {
+		if org, ok := strings.CutSuffix(host, "."+domain); ok && org != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("host must be a subdomain of %s", strings.Join(oktaDomains, ", "))
+}
+
+// settingDuration requires settings[key] to be a positive Go duration such
+// as "30s" or "5m".
+func settingDuration(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key].(string)
+		if !ok {
+			return diags
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting %q must be a positive duration such as \"30s\" or \"5m\".", key, value),
+			)
+		}
+		return diags
+	}
+}
+
+// settingPort requires settings[key] to be a TCP port number.
+func settingPort(key string) connectorRule {
+	return settingIntRange(key, 1, 65535)
+}
+
+// settingIntRange requires settings[key] to be a whole number in [lo, hi].
+func settingIntRange(key string, lo, hi int64) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key]
+		if !ok || value == nil {
+			return diags
+		}
+		n, ok := coerceInt64(value)
+		if !ok || n < lo || n > hi {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting must be a whole number between %d and %d, got %v.", key, lo, hi, value),
+			)
+		}
+		return diags
+	}
+}
+
+// settingPositiveInt requires settings[key] to be a whole number above zero.
+func settingPositiveInt(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key]
+		if !ok || value == nil {
+			return diags
+		}
+		if n, ok := coerceInt64(value); !ok || n < 1 {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting must be a whole number greater than zero, got %v.", key, value),
+			)
+		}
+		return diags
+	}
+}
+
+// coerceInt64 converts a decoded numeric setting to int64. Numbers arrive as
+// int64 from Terraform and as float64 from JSON API responses; fractional or
+// out-of-range values are rejected rather than truncated.
+func coerceInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// settingRequiredWhen requires settings[required] to be a non-blank string
+// whenever settings[key] equals value.
+func settingRequiredWhen(key, value, required string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		if v, _ := cfg.settings[key].(string); v != value {
+			return diags
+		}
+		if s, _ := cfg.settings[required].(string); strings.TrimSpace(s) == "" {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Missing %s", required),
+				fmt.Sprintf("The %s setting is required when %s is %q.", required, key, value),
+			)
+		}
+		return diags
+	}
+}
+
+// settingsTogether requires every one of keys as soon as any of them is set,
+// for settings that only make sense as a group, such as the parts of a
+// connection target. A partial group would otherwise reach the API and be
+// rejected with an error that does not name the missing setting.
+func settingsTogether(keys ...string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		var missing []string
+		for _, key := range keys {
+			if s, _ := cfg.settings[key].(string); strings.TrimSpace(s) == "" {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) == 0 || len(missing) == len(keys) {
+			return diags
+		}
+		diags.AddAttributeError(
+			settingsPath,
+			"Incomplete settings",
+			fmt.Sprintf("%s must be set together; missing %s.", strings.Join(keys, ", "), strings.Join(missing, ", ")),
+		)
+		return diags
+	}
+}
+
+// settingsRecommendOneOf warns, without failing validation, when none of keys
+// is set. reason explains what the API does in their absence.
+func settingsRecommendOneOf(reason string, keys ...string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for _, key := range keys {
+			if cfg.settings[key] != nil {
+				return diags
+			}
+		}
+		diags.AddAttributeWarning(
+			settingsPath,
+			"Missing recommended settings",
+			fmt.Sprintf("None of %s is set. %s", strings.Join(keys, ", "), reason),
+		)
+		return diags
+	}
+}
+
+// settingRequiresSecret requires secrets[secret] whenever settings[setting]
+// is set, for settings that only make sense with a secret, such as a signing
+// algorithm and its key.
+func settingRequiresSecret(setting, secret string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		if cfg.settings[setting] != nil && cfg.secrets[secret] == nil {
+			diags.AddAttributeError(
+				secretsPath,
+				fmt.Sprintf("Missing %s", secret),
+				fmt.Sprintf("The %s secret is required when the %s setting is set.", secret, setting),
+			)
+		}
+		return diags
+	}
+}
+
+// secretJSON requires secrets[key] to be a JSON document, such as a cloud
+// service account key. The value is never echoed back.
+func secretJSON(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := secretValue(cfg.secrets[key])
+		if ok && !json.Valid([]byte(value)) {
+			diags.AddAttributeError(
+				secretsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s secret must be a JSON document, such as the contents of a key file.", key),
+			)
+		}
+		return diags
+	}
+}
+
+// settingPEM requires settings[key], when set, to hold at least one PEM block,
+// such as a CA certificate bundle.
+func settingPEM(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := cfg.settings[key].(string)
+		if ok && !isPEM(value) {
+			diags.AddAttributeError(
+				settingsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s setting must be PEM-encoded, starting with a \"-----BEGIN\" line.", key),
+			)
+		}
+		return diags
+	}
+}
+
+// secretPEM is settingPEM for secrets, such as a client certificate or key.
+// The value is never echoed back.
+func secretPEM(key string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		value, ok := secretValue(cfg.secrets[key])
+		if ok && !isPEM(value) {
+			diags.AddAttributeError(
+				secretsPath,
+				fmt.Sprintf("Invalid %s", key),
+				fmt.Sprintf("The %s secret must be PEM-encoded, starting with a \"-----BEGIN\" line.", key),
+			)
+		}
+		return diags
+	}
+}
+
+func isPEM(value string) bool {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(value)))
+	return block != nil
+}
+
+// settingsAtMostOneOf rejects settings that set more than one of keys, such
+// as two spellings of the same option.
+func settingsAtMostOneOf(keys ...string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+		var set []string
+		for _, key := range keys {
+			if cfg.settings[key] != nil {
+				set = append(set, key)
+			}
+		}
+		if len(set) > 1 {
+			diags.AddAttributeError(
+				settingsPath,
+				"Conflicting settings",
+				fmt.Sprintf("Set only one of: %s.", strings.Join(keys, ", ")),
+			)
+		}
+		return diags
+	}
+}
+
+// secretsExactlyOneOf requires exactly one group of secrets to be set, and
+// every key of that group. Each group is an alternative way to authenticate,
+// such as a connection string versus separate credentials.
+func secretsExactlyOneOf(groups ...[]string) connectorRule {
+	return secretGroups(true, groups)
+}
+
+// secretsAtMostOneOf is secretsExactlyOneOf for connectors that may also run
+// without credentials.
+func secretsAtMostOneOf(groups ...[]string) connectorRule {
+	return secretGroups(false, groups)
+}
+
+func secretGroups(required bool, groups [][]string) connectorRule {
+	return func(cfg connectorConfig) diag.Diagnostics {
+		var diags diag.Diagnostics
+
+		var set [][]string
+		for _, group := range groups {
+			if slices.ContainsFunc(group, func(key string) bool { return cfg.secrets[key] != nil }) {
+				set = append(set, group)
+			}
+		}
+
+		alternatives := make([]string, len(groups))
+		for i, group := range groups {
+			alternatives[i] = strings.Join(group, " + ")
+		}
+
+		switch len(set) {
+		case 0:
+			if required {
+				diags.AddAttributeError(
+					secretsPath,
+					"Missing credentials",
+					fmt.Sprintf("Set one of: %s.", strings.Join(alternatives, "; or ")),
+				)
+			}
+		case 1:
+			var missing []string
+			for _, key := range set[0] {
+				if cfg.secrets[key] == nil {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				diags.AddAttributeError(
+					secretsPath,
+					"Incomplete credentials",
+					fmt.Sprintf("%s must be set together; missing %s.", strings.Join(set[0], ", "), strings.Join(missing, ", ")),
+				)
+			}
+		default:
+			diags.AddAttributeError(
+				secretsPath,
+				"Conflicting credentials",
+				fmt.Sprintf("Set only one of: %s.", strings.Join(alternatives, "; or ")),
+			)
+		}
+		return diags
+	}
+}
+
+// checkConnectorConfig runs rules against cfg.
+func checkConnectorConfig(rules []connectorRule, cfg connectorConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, rule := range rules {
+		diags.Append(rule(cfg)...)
+	}
+	return diags
+}
+
+// validateConnectorConfig decodes a connector's configuration and runs the
+// rules registered for its `type`, after the secrets shape check every type
+// shares. Unknown values are skipped; they are validated again once known.
+func validateConnectorConfig(ctx context.Context, config tfsdk.Config, rules map[string][]connectorRule) diag.Diagnostics {
+	diags := validateConnectorSecretsShape(ctx, config)
+	if diags.HasError() {
+		return diags
+	}
+
+	var connectorType types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("type"), &connectorType)...)
+	if diags.HasError() {
+		return diags
+	}
+	typeRules, ok := rules[connectorType.ValueString()]
+	if !ok {
+		return diags
+	}
+
+	var cfg connectorConfig
+	for _, target := range []struct {
+		path path.Path
+		dest *map[string]any
+	}{
+		{settingsPath, &cfg.settings},
+		{secretsPath, &cfg.secrets},
+	} {
+		var dyn types.Dynamic
+		if d := config.GetAttribute(ctx, target.path, &dyn); d.HasError() {
+			// config block absent — nothing to validate.
+			return diags
+		}
+		if dyn.IsUnknown() || dyn.IsUnderlyingValueUnknown() {
+			return diags
+		}
+		decoded, err := tfDynamicToMapAny(dyn)
+		if err != nil {
+			return diags
+		}
+		*target.dest = decoded
+	}
+
+	diags.Append(checkConnectorConfig(typeRules, cfg)...)
+	return diags
+}
+
+// validateConnectorSecretsShape checks the `config.secrets` shape shared by
+// every connector type: an object whose values are strings, references to
+// existing secrets (`{ id = "<secret id>" }`), inline secrets (`{ value }`,
+// optionally with `name` and `description`), or objects of those, such as the HTTP output's
+// `auth_headers`.
+func validateConnectorSecretsShape(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var secrets types.Dynamic
+	if d := config.GetAttribute(ctx, secretsPath, &secrets); d.HasError() {
+		// config block absent — nothing to validate.
+		return diags
+	}
+	if secrets.IsNull() || secrets.IsUnknown() || secrets.IsUnderlyingValueUnknown() {
+		return diags
+	}
+
+	elements, ok := objectElements(secrets.UnderlyingValue())
+	if !ok {
+		diags.AddAttributeError(
+			secretsPath,
+			"Invalid secrets",
+			fmt.Sprintf(
+				"config.secrets must be an object of secret names to values, e.g. "+
+					"{ api_key = \"...\" }, got %s.",
+				valueKind(secrets.UnderlyingValue()),
+			),
+		)
+		return diags
+	}
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		checkSecretValue(&diags, "config.secrets."+key, elements[key])
+	}
+	return diags
+}
+
+// Keys of the connector secret object (the API's ModelsSecret): `id`
+// references an existing secret, `value` defines one inline.
+const (
+	secretIDKey    = "id"
+	secretValueKey = "value"
+)
+
+// inlineSecretKeys are the keys of an inline secret object. Only `value` is
+// required.
+var inlineSecretKeys = []string{secretValueKey, "name", "description"}
+
+// checkSecretValue reports a secret value at location that is not a string, a
+// `{ id }` reference, a `{ value }` secret (optionally with `name` and
+// `description`), or an object of those. Null and unknown values pass. Other
+// keys on an inline secret are warned about rather than rejected.
+func checkSecretValue(diags *diag.Diagnostics, location string, value attr.Value) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if dyn, ok := value.(types.Dynamic); ok {
+		if dyn.IsUnderlyingValueNull() || dyn.IsUnderlyingValueUnknown() {
+			return
+		}
+		value = dyn.UnderlyingValue()
+	}
+	if _, ok := value.(types.String); ok {
+		return
+	}
+
+	elements, ok := objectElements(value)
+	if !ok {
+		diags.AddAttributeError(
+			secretsPath,
+			"Invalid secret value",
+			fmt.Sprintf(
+				"%s must be a string, a reference to a secret such as { id = \"<secret id>\" }, "+
+					"or a secret such as { value = \"...\" }, got %s.",
+				location, valueKind(value),
+			),
+		)
+		return
+	}
+
+	if ref, isRef := elements[secretIDKey]; isRef {
+		id, isString := ref.(types.String)
+		if len(elements) != 1 || !isString || (!id.IsUnknown() && id.ValueString() == "") {
+			diags.AddAttributeError(
+				secretsPath,
+				"Invalid secret reference",
+				fmt.Sprintf(
+					"%s: a reference to a secret must be an object with only a non-empty %q string, e.g. { id = \"<secret id>\" }.",
+					location, secretIDKey,
+				),
+			)
+		}
+		return
+	}
+	if _, isInline := elements[secretValueKey]; isInline {
+		if problem := inlineSecretProblem(elements); problem != "" {
+			diags.AddAttributeError(
+				secretsPath,
+				"Invalid secret",
+				fmt.Sprintf(
+					"%s: a secret must be an object with a non-empty \"value\" string and optional "+
+						"\"name\" and \"description\" strings, e.g. { value = \"...\", name = \"api-key\" }; %s.",
+					location, problem,
+				),
+			)
+		}
+		for _, key := range slices.Sorted(maps.Keys(elements)) {
+			if !slices.Contains(inlineSecretKeys, key) {
+				diags.AddAttributeWarning(
+					secretsPath,
+					"Unknown secret key",
+					fmt.Sprintf(
+						"%s: %q is not one of the secret keys \"value\", \"name\" and \"description\".",
+						location, key,
+					),
+				)
+			}
+		}
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		checkSecretValue(diags, location+"."+key, elements[key])
+	}
+}
+
+// inlineSecretProblem describes what is wrong with an inline secret object,
+// or returns "" when its `value` is an unknown or non-empty string and any
+// `name` or `description` is a string.
+func inlineSecretProblem(elements map[string]attr.Value) string {
+	for _, key := range inlineSecretKeys {
+		value, present := elements[key]
+		if !present {
+			continue
+		}
+		str, isString := value.(types.String)
+		if !isString {
+			return fmt.Sprintf("%q must be a string", key)
+		}
+		if key == secretValueKey && !str.IsUnknown() && str.ValueString() == "" {
+			return fmt.Sprintf("%q must not be empty", key)
+		}
+	}
+	return ""
+}
+
+// objectElements returns the members of an object or map value.
+func objectElements(value attr.Value) (map[string]attr.Value, bool) {
+	switch v := value.(type) {
+	case types.Object:
+		return v.Attributes(), true
+	case types.Map:
+		return v.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
+// valueKind names the kind of a non-object value for diagnostics, e.g.
+// "a list".
+func valueKind(value attr.Value) string {
+	switch value.(type) {
+	case types.List, types.Tuple, types.Set:
+		return "a list"
+	case types.String:
+		return "a string"
+	case types.Number, types.Int64, types.Float64:
+		return "a number"
+	case types.Bool:
+		return "a bool"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}